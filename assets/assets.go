@@ -0,0 +1,10 @@
+// Package assets embeds static files shipped with goreleaser-wizard.
+package assets
+
+import "embed"
+
+// Licenses holds the license templates as licenses/<SPDX-ID>.template.
+// Templates use {{YEAR}} and {{COPYRIGHT_HOLDER}} placeholders.
+//
+//go:embed licenses/*.template
+var Licenses embed.FS
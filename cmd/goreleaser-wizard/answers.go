@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// gitProviders lists the supported git providers in display order
+var gitProviders = []string{
+	"GitHub",
+	"GitLab",
+	"Gitea",
+	"Local Only",
+}
+
+// actionsTriggers pairs each --trigger value with its GitHub Actions trigger answer
+var actionsTriggers = []struct {
+	Flag   string
+	Answer string
+}{
+	{"tags", "On version tags (v*)"},
+	{"all-tags", "On all tags"},
+	{"manual", "Manual trigger only"},
+	{"main", "On push to main"},
+}
+
+// MissingAnswer names a required answer and the flag that provides it
+type MissingAnswer struct {
+	Question string
+	Flag     string
+}
+
+// addAnswerFlags registers the flags that answer wizard questions. Every
+// answer can also come from the config file or a GORELEASER_WIZARD_* variable
+// named after the flag.
+func addAnswerFlags(flags *pflag.FlagSet) {
+	// Basic info
+	flags.String("name", "", "project name")
+	flags.String("description", "", "project description")
+	flags.String("binary", "", "binary name")
+	flags.String("main", ".", "path to main.go")
+	flags.String("license", "", "SPDX license identifier (default detected from LICENSE, else MIT)")
+
+	// Build options
+	flags.StringSlice("platforms", []string{"linux", "darwin", "windows"}, "target platforms")
+	flags.StringSlice("architectures", []string{"amd64", "arm64"}, "target architectures")
+	flags.Bool("cgo", false, "enable CGO")
+	flags.Bool("ldflags", false, "embed version, commit and date in the binary")
+	flags.StringSlice("goarm", nil, "GOARM variants for arm builds (5, 6, 7)")
+	flags.StringSlice("goamd64", nil, "GOAMD64 variants for amd64 builds (v1-v4)")
+	flags.StringSlice("gomips", nil, "GOMIPS variants for mips builds (hardfloat, softfloat)")
+	flags.StringArray("override", nil, "per-target override as goos/goarch[/variant]:env=K=V;flags=...;ldflags=...;tags=... (repeatable)")
+	flags.StringSlice("tags", nil, "build tags (default netgo,osusergo when CGO is disabled)")
+
+	// Release options
+	flags.String("provider", "GitHub", "git provider: GitHub, GitLab, Gitea or Local Only")
+	flags.Bool("docker", false, "enable Docker builds")
+	flags.String("registry", "", "Docker registry, e.g. ghcr.io/owner")
+	flags.Bool("signing", false, "enable code signing")
+	flags.Bool("sbom", false, "generate SBOMs")
+	flags.Bool("homebrew", false, "publish a Homebrew formula")
+	flags.Bool("snap", false, "publish a Snap package")
+
+	// Advanced options
+	flags.Bool("github-action", false, "generate GitHub Actions workflow")
+	flags.StringSlice("trigger", []string{"tags"}, "workflow triggers: tags, all-tags, manual, main")
+	flags.String("compression", "gzip", "archive compression: none, gzip, zstd, xz or zip")
+	flags.Bool("upx", false, "compress binaries with UPX")
+	flags.Bool("universal-binaries", false, "build universal macOS binaries")
+	flags.StringArray("hook", nil, "extra before hook command (repeatable)")
+	flags.StringArray("post-build-hook", nil, "command to run after each build (repeatable)")
+	flags.Bool("skip-tests", false, "do not run 'go test ./...' as a before hook")
+	flags.Bool("minimal", false, "create minimal configuration")
+
+	// Pro options
+	flags.Bool("pro", false, "use GoReleaser Pro features")
+	flags.StringSlice("nfpm", nil, "Linux package formats: deb, rpm, apk (Pro)")
+	flags.Bool("changelog-groups", true, "group changelog by commit type (Pro)")
+	flags.Bool("source-archive", false, "publish a source archive (Pro)")
+	flags.Bool("scoop", false, "publish a Scoop manifest (Pro)")
+	flags.Bool("nightly", false, "publish nightly releases (Pro)")
+	flags.Bool("partial", false, "split builds per GOOS and merge before publishing (Pro)")
+	flags.String("monorepo-prefix", "", "monorepo tag prefix, e.g. app/ (Pro)")
+	flags.String("monorepo-dir", "", "monorepo project directory (Pro)")
+	flags.StringArray("before-publish", nil, "command to run before publishing (Pro, repeatable)")
+	flags.StringArray("templated-file", nil, "templated release file as src[:dst] (Pro, repeatable)")
+	flags.StringArray("include", nil, "shared config fragment path or URL (Pro, repeatable)")
+
+	// Template pack questions
+	flags.StringArray("set", nil, "answer a template pack question as id=value (repeatable)")
+}
+
+// applyAnswerFlags copies answers onto config. Flags given on the command
+// line win, then config file and environment values; flag defaults only
+// fill answers that are still empty (e.g. not detected).
+func applyAnswerFlags(cmd *cobra.Command, config *wizard.ProjectConfig) error {
+	setString(cmd, "name", &config.ProjectName)
+	setString(cmd, "description", &config.ProjectDescription)
+	setString(cmd, "binary", &config.BinaryName)
+	setString(cmd, "main", &config.MainPath)
+	setString(cmd, "license", &config.License)
+
+	setStrings(cmd, "platforms", &config.Platforms)
+	setStrings(cmd, "architectures", &config.Architectures)
+	setBool(cmd, "cgo", &config.CGOEnabled)
+	setBool(cmd, "ldflags", &config.LDFlags)
+	setStrings(cmd, "goarm", &config.GoARM)
+	setStrings(cmd, "goamd64", &config.GoAMD64)
+	setStrings(cmd, "gomips", &config.GoMIPS)
+	setStrings(cmd, "tags", &config.BuildTags)
+
+	var overrideSpecs []string
+	setStrings(cmd, "override", &overrideSpecs)
+	if len(overrideSpecs) > 0 {
+		overrides, err := wizard.ParseBuildOverrides(overrideSpecs)
+		if err != nil {
+			return UserInputError("build override", err)
+		}
+		config.BuildOverrides = overrides
+	}
+
+	var provider string
+	setString(cmd, "provider", &provider)
+	if answerGiven(cmd, "provider") || config.GitProvider == "" {
+		canonical, err := canonicalProvider(provider)
+		if err != nil {
+			return UserInputError("git provider", err)
+		}
+		config.GitProvider = canonical
+	}
+	setBool(cmd, "docker", &config.DockerEnabled)
+	setString(cmd, "registry", &config.DockerRegistry)
+	setBool(cmd, "signing", &config.Signing)
+	setBool(cmd, "sbom", &config.SBOM)
+	setBool(cmd, "homebrew", &config.Homebrew)
+	setBool(cmd, "snap", &config.Snap)
+
+	setBool(cmd, "github-action", &config.GenerateActions)
+	if answerGiven(cmd, "trigger") || len(config.ActionsOn) == 0 {
+		var triggers []string
+		setStrings(cmd, "trigger", &triggers)
+		actionsOn, err := canonicalTriggers(triggers)
+		if err != nil {
+			return UserInputError("workflow trigger", err)
+		}
+		config.ActionsOn = actionsOn
+	}
+	setString(cmd, "compression", &config.Compression)
+	setBool(cmd, "upx", &config.UPX)
+	setBool(cmd, "universal-binaries", &config.UniversalBinaries)
+	setStrings(cmd, "hook", &config.Hooks)
+	setStrings(cmd, "post-build-hook", &config.PostBuildHooks)
+	setBool(cmd, "skip-tests", &config.SkipTests)
+	setBool(cmd, "minimal", &config.Minimal)
+
+	setBool(cmd, "pro", &config.ProVersion)
+	setStrings(cmd, "nfpm", &config.NFPMFormats)
+	setBool(cmd, "changelog-groups", &config.ChangelogGroups)
+	setBool(cmd, "source-archive", &config.SourceArchive)
+	setBool(cmd, "scoop", &config.Scoop)
+	setBool(cmd, "nightly", &config.Nightly)
+	setBool(cmd, "partial", &config.Partial)
+	setString(cmd, "monorepo-prefix", &config.MonorepoPrefix)
+	setString(cmd, "monorepo-dir", &config.MonorepoDir)
+	setStrings(cmd, "before-publish", &config.BeforePublish)
+	setStrings(cmd, "include", &config.Includes)
+
+	var templatedFiles []string
+	setStrings(cmd, "templated-file", &templatedFiles)
+	if len(templatedFiles) > 0 {
+		config.TemplatedFiles = wizard.ParseTemplatedFiles(templatedFiles)
+	}
+	normalizeMonorepo(config)
+
+	if err := applyCustomAnswers(cmd, config); err != nil {
+		return err
+	}
+
+	return validateAnswers(config)
+}
+
+// applyCustomAnswers sets template pack answers from the custom map in the
+// config file, then from --set id=value
+func applyCustomAnswers(cmd *cobra.Command, config *wizard.ProjectConfig) error {
+	custom := viper.GetStringMapString("custom")
+	var sets []string
+	setStrings(cmd, "set", &sets)
+	for _, set := range sets {
+		id, value, ok := strings.Cut(set, "=")
+		if !ok || id == "" {
+			return UserInputError("template pack answer", fmt.Errorf("%q is not id=value", set))
+		}
+		if custom == nil {
+			custom = make(map[string]string)
+		}
+		custom[id] = value
+	}
+	if len(custom) > 0 {
+		config.Custom = custom
+	}
+	return nil
+}
+
+// validateAnswers checks answers that flags, config or environment may have set
+func validateAnswers(config *wizard.ProjectConfig) error {
+	if !config.ProVersion && (config.Nightly || config.Partial || config.MonorepoPrefix != "" ||
+		len(config.BeforePublish) > 0 || len(config.TemplatedFiles) > 0 || len(config.Includes) > 0) {
+		return NewWizardError(
+			ErrConfiguration,
+			"Pro-only options require --pro",
+			"--nightly, --partial, --monorepo-prefix, --before-publish, --templated-file and --include need GoReleaser Pro",
+			"Add --pro or remove the Pro-only flags",
+			nil,
+		)
+	}
+
+	for _, variants := range []struct {
+		name   string
+		values []string
+		valid  []string
+	}{
+		{"goarm", config.GoARM, wizard.GoarmOptions},
+		{"goamd64", config.GoAMD64, wizard.Goamd64Options},
+		{"gomips", config.GoMIPS, wizard.GomipsOptions},
+	} {
+		if err := wizard.ValidateVariants(variants.name, variants.values, variants.valid); err != nil {
+			return UserInputError(variants.name+" variants", err)
+		}
+	}
+
+	if err := wizard.ValidateCompression(config.Compression); err != nil {
+		return UserInputError("compression", err)
+	}
+
+	return nil
+}
+
+// missingAnswers lists required answers that are still empty
+func missingAnswers(config *wizard.ProjectConfig) []MissingAnswer {
+	var missing []MissingAnswer
+	if config.ProjectName == "" {
+		missing = append(missing, MissingAnswer{"Project name", "--name"})
+	}
+	if config.MainPath == "" {
+		missing = append(missing, MissingAnswer{"Main package path", "--main"})
+	}
+	if config.DockerEnabled && config.DockerRegistry == "" {
+		missing = append(missing, MissingAnswer{"Docker registry", "--registry"})
+	}
+	return missing
+}
+
+// isInteractive reports whether stdin and stdout are terminals
+func isInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is a terminal, including Cygwin/MSYS terminals
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// answerGiven reports whether an answer came from a flag, the config file or the environment
+func answerGiven(cmd *cobra.Command, name string) bool {
+	return cmd.Flags().Changed(name) || viper.IsSet(name)
+}
+
+func setString(cmd *cobra.Command, name string, target *string) {
+	switch {
+	case cmd.Flags().Changed(name):
+		*target, _ = cmd.Flags().GetString(name)
+	case viper.IsSet(name):
+		*target = viper.GetString(name)
+	case *target == "":
+		*target, _ = cmd.Flags().GetString(name)
+	}
+}
+
+func setBool(cmd *cobra.Command, name string, target *bool) {
+	switch {
+	case cmd.Flags().Changed(name):
+		*target, _ = cmd.Flags().GetBool(name)
+	case viper.IsSet(name):
+		*target = viper.GetBool(name)
+	case !*target:
+		*target, _ = cmd.Flags().GetBool(name)
+	}
+}
+
+func setStrings(cmd *cobra.Command, name string, target *[]string) {
+	flag := cmd.Flags().Lookup(name)
+	get := func() []string {
+		if flag.Value.Type() == "stringArray" {
+			values, _ := cmd.Flags().GetStringArray(name)
+			return values
+		}
+		values, _ := cmd.Flags().GetStringSlice(name)
+		return values
+	}
+
+	switch {
+	case cmd.Flags().Changed(name):
+		*target = get()
+	case viper.IsSet(name):
+		*target = viper.GetStringSlice(name)
+	case len(*target) == 0:
+		*target = get()
+	}
+}
+
+// canonicalProvider matches a provider name case-insensitively
+func canonicalProvider(provider string) (string, error) {
+	for _, known := range gitProviders {
+		if strings.EqualFold(known, provider) || strings.EqualFold(strings.ReplaceAll(known, " ", "-"), provider) {
+			return known, nil
+		}
+	}
+	if strings.EqualFold(provider, "local") {
+		return "Local Only", nil
+	}
+	return "", fmt.Errorf("unknown provider %q (valid: %s)", provider, strings.Join(gitProviders, ", "))
+}
+
+// canonicalTriggers maps --trigger values to workflow trigger answers
+func canonicalTriggers(triggers []string) ([]string, error) {
+	var actionsOn, valid []string
+	for _, known := range actionsTriggers {
+		valid = append(valid, known.Flag)
+	}
+
+	for _, trigger := range triggers {
+		known := false
+		for _, candidate := range actionsTriggers {
+			if strings.EqualFold(candidate.Flag, trigger) || candidate.Answer == trigger {
+				actionsOn = append(actionsOn, candidate.Answer)
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown trigger %q (valid: %s)", trigger, strings.Join(valid, ", "))
+		}
+	}
+	return actionsOn, nil
+}
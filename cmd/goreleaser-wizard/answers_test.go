@@ -0,0 +1,113 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/spf13/cobra"
+)
+
+func newAnswerCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addAnswerFlags(cmd.Flags())
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestApplyAnswerFlags(t *testing.T) {
+	// Detected answers survive unless a flag overrides them
+	config := &wizard.ProjectConfig{ProjectName: "detected", MainPath: "./cmd/detected"}
+	cmd := newAnswerCommand(t, "--binary", "tool", "--provider", "gitlab", "--trigger", "tags,manual", "--docker", "--registry", "ghcr.io/me")
+
+	if err := applyAnswerFlags(cmd, config); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.ProjectName != "detected" || config.MainPath != "./cmd/detected" {
+		t.Errorf("Detected answers overwritten: name=%q main=%q", config.ProjectName, config.MainPath)
+	}
+	if config.BinaryName != "tool" {
+		t.Errorf("BinaryName = %q, want tool", config.BinaryName)
+	}
+	if config.GitProvider != "GitLab" {
+		t.Errorf("GitProvider = %q, want GitLab", config.GitProvider)
+	}
+	if want := []string{"On version tags (v*)", "Manual trigger only"}; !slices.Equal(config.ActionsOn, want) {
+		t.Errorf("ActionsOn = %v, want %v", config.ActionsOn, want)
+	}
+	if !slices.Equal(config.Platforms, []string{"linux", "darwin", "windows"}) {
+		t.Errorf("Platforms default not applied: %v", config.Platforms)
+	}
+	if config.Compression != "gzip" || !config.ChangelogGroups {
+		t.Errorf("Defaults not applied: compression=%q changelog groups=%v", config.Compression, config.ChangelogGroups)
+	}
+}
+
+func TestApplyAnswerFlagsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown_provider", []string{"--provider", "svn"}},
+		{"unknown_trigger", []string{"--trigger", "nightly"}},
+		{"invalid_variant", []string{"--goarm", "8"}},
+		{"invalid_compression", []string{"--compression", "rar"}},
+		{"pro_only_without_pro", []string{"--nightly"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := applyAnswerFlags(newAnswerCommand(t, tt.args...), &wizard.ProjectConfig{}); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestMissingAnswers(t *testing.T) {
+	config := &wizard.ProjectConfig{DockerEnabled: true}
+
+	missing := missingAnswers(config)
+	var flags []string
+	for _, answer := range missing {
+		flags = append(flags, answer.Flag)
+	}
+	if want := []string{"--name", "--main", "--registry"}; !slices.Equal(flags, want) {
+		t.Errorf("Missing flags = %v, want %v", flags, want)
+	}
+
+	err := MissingAnswersError(missing)
+	for _, want := range []string{"--name", "--main", "--registry"} {
+		if !strings.Contains(err.Suggestion, want) {
+			t.Errorf("Suggestion missing %q: %s", want, err.Suggestion)
+		}
+	}
+
+	config.ProjectName = "app"
+	config.MainPath = "."
+	config.DockerRegistry = "ghcr.io/me"
+	if missing := missingAnswers(config); len(missing) != 0 {
+		t.Errorf("Expected no missing answers, got %v", missing)
+	}
+}
+
+func TestApplyCustomAnswers(t *testing.T) {
+	config := &wizard.ProjectConfig{}
+	cmd := newAnswerCommand(t, "--set", "team=payments", "--set", "label=a=b")
+	if err := applyCustomAnswers(cmd, config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Custom["team"] != "payments" || config.Custom["label"] != "a=b" {
+		t.Errorf("Custom = %v", config.Custom)
+	}
+
+	cmd = newAnswerCommand(t, "--set", "team")
+	if err := applyCustomAnswers(cmd, &wizard.ProjectConfig{}); ExitCode(err) != ExitUsage {
+		t.Errorf("applyCustomAnswers() with no '=' = %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/template"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch <dir|glob>...",
+	Short: "Generate GoReleaser configurations across many repositories",
+	Long: `Generate release files for many repositories in one run.
+
+Each argument is a repository directory or a glob such as 'services/*'.
+Detection runs in every repository; shared answers come from --answers,
+flags, the config file and the environment. A failure in one repository
+is reported and the rest carry on.
+
+Existing files that differ from the generated ones are only overwritten
+with --force; their diffs are shown either way. Use --dry-run to preview.`,
+	RunE: runBatch,
+}
+
+func init() {
+	addAnswerFlags(batchCmd.Flags())
+	batchCmd.Flags().String("answers", "", "YAML file of shared answers, keyed by flag name")
+	batchCmd.Flags().Bool("force", false, "overwrite existing files that differ")
+	batchCmd.Flags().Bool("dry-run", false, "report what would change without writing")
+	batchCmd.Flags().Bool("diff", true, "show diffs for changed files")
+	batchCmd.Flags().String("format", "text", "output format: text or json")
+}
+
+// Batch statuses, one per repository
+const (
+	batchCreated = "created"
+	batchChanged = "changed"
+	batchSkipped = "skipped"
+	batchFailed  = "failed"
+)
+
+// batchStatuses lists the statuses in summary order
+var batchStatuses = []string{batchCreated, batchChanged, batchSkipped, batchFailed}
+
+// batchResult is the outcome for one repository
+type batchResult struct {
+	Repo   string   `json:"repo"`
+	Status string   `json:"status"`
+	Files  []string `json:"files,omitempty"`
+	Detail string   `json:"detail,omitempty"`
+	Diff   string   `json:"diff,omitempty"`
+}
+
+// batchOptions controls how each repository is processed
+type batchOptions struct {
+	Force     bool
+	DryRun    bool
+	Templates *template.Template
+	Outputs   []wizard.Output
+	Pack      *wizard.Pack
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	// Set up logger
+	logger := newLogger()
+
+	// Set up panic recovery
+	defer HandlePanic("batch command", logger)
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		return UserInputError("output format", fmt.Errorf("unknown format %q (valid: text, json)", format))
+	}
+	if len(args) == 0 {
+		return UserInputError("repositories", errors.New("no repository directories or globs given"))
+	}
+
+	if answers, _ := cmd.Flags().GetString("answers"); answers != "" {
+		if err := loadAnswersFile(answers); err != nil {
+			return err
+		}
+	}
+	// Shared answers are the same for every repository, so check them once
+	if err := applyAnswerFlags(cmd, &wizard.ProjectConfig{}); err != nil {
+		return err
+	}
+
+	repos, err := batchRepos(args)
+	if err != nil {
+		return err
+	}
+
+	var opts batchOptions
+	if opts.Templates, opts.Outputs, err = loadTemplates(); err != nil {
+		return err
+	}
+	if opts.Pack, err = loadPack(); err != nil {
+		return err
+	}
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	showDiff, _ := cmd.Flags().GetBool("diff")
+
+	// Ctrl-C stops before the next repository and still reports what finished
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var results []batchResult
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			break
+		}
+		logger.Debug("Processing repository", "repo", repo)
+		result := processRepo(cmd, repoRoot(repo), opts)
+		if result.Status == batchFailed {
+			logger.Debug("Repository failed", "repo", repo, "error", result.Detail)
+		}
+		results = append(results, result)
+	}
+
+	if format == "json" {
+		if err := writeBatchJSON(os.Stdout, results); err != nil {
+			return NewWizardError(ErrFileWrite, "Failed to write batch report", err.Error(), "Check that stdout is writable", err)
+		}
+	} else {
+		writeBatchText(os.Stdout, results, showDiff, opts.DryRun)
+	}
+
+	if ctx.Err() != nil {
+		return NewWizardError(ErrCancelled, "Batch cancelled", fmt.Sprintf("Stopped after %d of %d repositories", len(results), len(repos)), "Run batch again to process the rest", ctx.Err())
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Status == batchFailed {
+			failed = append(failed, result.Repo)
+		}
+	}
+	if len(failed) > 0 {
+		return NewWizardError(
+			ErrBatchFailed,
+			fmt.Sprintf("Batch failed for %d of %d repositories", len(failed), len(results)),
+			strings.Join(failed, ", "),
+			"Fix the failed repositories and run batch again; the others were processed",
+			nil,
+		)
+	}
+	return nil
+}
+
+// loadAnswersFile merges a YAML answers file into the config layer, so its
+// answers apply like config file answers and flags still win
+func loadAnswersFile(path string) error {
+	data, err := SafeReadFile(wizard.Root{}, path)
+	if err != nil {
+		return err
+	}
+	var answers map[string]any
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return NewWizardError(ErrConfiguration, "Invalid answers file", fmt.Sprintf("%s: %v", path, err), "Write answers as YAML keyed by flag name, e.g. 'docker: true'", err)
+	}
+	if err := viper.MergeConfigMap(answers); err != nil {
+		return NewWizardError(ErrConfiguration, "Invalid answers file", fmt.Sprintf("%s: %v", path, err), "Write answers as YAML keyed by flag name, e.g. 'docker: true'", err)
+	}
+	return nil
+}
+
+// batchRepos expands the arguments into repository directories, relative to
+// --dir. Globs keep only directories; plain paths are kept as given so a
+// missing repository shows up as failed.
+func batchRepos(args []string) ([]string, error) {
+	var repos []string
+	seen := map[string]bool{}
+	add := func(repo string) {
+		if !seen[repo] {
+			seen[repo] = true
+			repos = append(repos, repo)
+		}
+	}
+
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			add(projectRoot.Path(arg))
+			continue
+		}
+		matches, err := projectRoot.Glob(arg)
+		if err != nil {
+			return nil, UserInputError("repository glob", err)
+		}
+		found := false
+		for _, match := range matches {
+			if repoRoot(match).Check() == nil {
+				add(match)
+				found = true
+			}
+		}
+		if !found {
+			return nil, UserInputError("repository glob", fmt.Errorf("no directories match %q", arg))
+		}
+	}
+	return repos, nil
+}
+
+// processRepo detects, renders and writes one repository. Errors and panics
+// are recorded in the result instead of stopping the batch.
+func processRepo(cmd *cobra.Command, root wizard.Root, opts batchOptions) (result batchResult) {
+	result.Repo = root.Dir
+	fail := func(err error) batchResult {
+		result.Status = batchFailed
+		result.Detail = errorDetail(err)
+		return result
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result = fail(fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	if err := root.Check(); err != nil {
+		return fail(err)
+	}
+	if !root.Exists("go.mod") {
+		result.Status = batchSkipped
+		result.Detail = "no go.mod"
+		return result
+	}
+
+	config := &wizard.ProjectConfig{}
+	if err := detectProjectInfo(root, config); err != nil {
+		return fail(err)
+	}
+	if err := applyAnswerFlags(cmd, config); err != nil {
+		return fail(err)
+	}
+	if config.BinaryName == "" {
+		config.BinaryName = config.ProjectName
+	}
+	if missing := missingAnswers(config); len(missing) > 0 {
+		return fail(MissingAnswersError(missing))
+	}
+	if err := checkPackAnswers(opts.Pack, config); err != nil {
+		return fail(err)
+	}
+
+	files, err := wizard.RenderWith(*config, opts.Templates, opts.Outputs...)
+	if err != nil {
+		return fail(renderError(err))
+	}
+
+	// Compare with what is on disk; identical files are left alone
+	pending := map[string][]byte{}
+	var changed []string
+	for _, name := range fileOrder(files) {
+		content := files[name]
+		existing, err := root.ReadFile(name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return fail(WrapFileError("read file", root.Path(name), err))
+		case bytes.Equal(existing, content):
+			continue
+		default:
+			changed = append(changed, name)
+			result.Diff += wizard.Diff(name, existing, content)
+		}
+		pending[name] = content
+		result.Files = append(result.Files, name)
+	}
+
+	switch {
+	case len(pending) == 0:
+		result.Status = batchSkipped
+		result.Detail = "up to date"
+		return result
+	case len(changed) > 0 && !opts.Force:
+		result.Status = batchSkipped
+		result.Files = nil
+		result.Detail = strings.Join(changed, ", ") + " differs; use --force to overwrite"
+		return result
+	case len(changed) > 0:
+		result.Status = batchChanged
+	default:
+		result.Status = batchCreated
+	}
+
+	if opts.DryRun {
+		result.Detail = "dry run"
+		return result
+	}
+	// Differing files were checked above, so overwrite what is left
+	if _, err := wizard.NewWriter(root).Commit(pending, true); err != nil {
+		return fail(commitError(err))
+	}
+	return result
+}
+
+// repoRoot is a repository directory on the same filesystem as --dir
+func repoRoot(repo string) wizard.Root {
+	return wizard.Root{Dir: repo, FS: projectRoot.FS}
+}
+
+// errorDetail summarizes err on one line for the batch table
+func errorDetail(err error) string {
+	var wizErr *WizardError
+	if errors.As(err, &wizErr) {
+		if wizErr.Details != "" {
+			return wizErr.Message + ": " + wizErr.Details
+		}
+		return wizErr.Message
+	}
+	return err.Error()
+}
+
+// writeBatchText prints the diffs, then a table with one row per repository
+func writeBatchText(w io.Writer, results []batchResult, showDiff, dryRun bool) {
+	title := "📦 Batch Summary"
+	if dryRun {
+		title += " (dry run)"
+	}
+
+	if showDiff {
+		for _, result := range results {
+			if result.Diff == "" {
+				continue
+			}
+			fmt.Fprintln(w, sectionStyle.Render(result.Repo))
+			for _, line := range strings.Split(strings.TrimSuffix(result.Diff, "\n"), "\n") {
+				switch {
+				case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+					fmt.Fprintln(w, line)
+				case strings.HasPrefix(line, "+"):
+					fmt.Fprintln(w, successStyle.Render(line))
+				case strings.HasPrefix(line, "-"):
+					fmt.Fprintln(w, errorStyle.Render(line))
+				case strings.HasPrefix(line, "@@"):
+					fmt.Fprintln(w, infoStyle.Render(line))
+				default:
+					fmt.Fprintln(w, line)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	repoWidth := len("REPOSITORY")
+	for _, result := range results {
+		repoWidth = max(repoWidth, len(result.Repo))
+	}
+
+	fmt.Fprintln(w, titleStyle.Render(title))
+	fmt.Fprintf(w, "%-*s  %-8s  %s\n", repoWidth, "REPOSITORY", "STATUS", "DETAIL")
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+		style := infoStyle
+		switch result.Status {
+		case batchCreated, batchChanged:
+			style = successStyle
+		case batchFailed:
+			style = errorStyle
+		}
+		detail := strings.Join(result.Files, ", ")
+		if detail == "" {
+			detail = result.Detail
+		} else if result.Detail != "" {
+			detail += " (" + result.Detail + ")"
+		}
+		// Pad outside the style so colors don't upset the columns
+		status := style.Render(result.Status) + strings.Repeat(" ", 8-len(result.Status))
+		fmt.Fprintf(w, "%-*s  %s  %s\n", repoWidth, result.Repo, status, detail)
+	}
+
+	var summary []string
+	for _, status := range batchStatuses {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.Join(summary, ", "))
+}
+
+// batchJSON is the batch --format json document
+type batchJSON struct {
+	Created int           `json:"created"`
+	Changed int           `json:"changed"`
+	Skipped int           `json:"skipped"`
+	Failed  int           `json:"failed"`
+	Repos   []batchResult `json:"repos"`
+}
+
+func writeBatchJSON(w io.Writer, results []batchResult) error {
+	doc := batchJSON{Repos: results}
+	if doc.Repos == nil {
+		doc.Repos = []batchResult{}
+	}
+	for _, result := range results {
+		switch result.Status {
+		case batchCreated:
+			doc.Created++
+		case batchChanged:
+			doc.Changed++
+		case batchSkipped:
+			doc.Skipped++
+		case batchFailed:
+			doc.Failed++
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+// newMemRoot returns an empty in-memory directory to hold repositories
+func newMemRoot(t *testing.T) wizard.Root {
+	t.Helper()
+	root := wizard.Root{Dir: "/work", FS: wizard.NewMemFS()}
+	if err := root.MkdirAll("."); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// newGoRepo creates a minimal Go module with a main package under dir
+func newGoRepo(t *testing.T, dir wizard.Root, name string) wizard.Root {
+	t.Helper()
+	repo := wizard.Root{Dir: dir.Path(name), FS: dir.FS}
+	if err := repo.MkdirAll("."); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"go.mod":  "module example.com/" + name + "\n\ngo 1.23\n",
+		"main.go": "package main\n",
+	}
+	for file, content := range files {
+		if err := repo.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+// newBatchOptions returns options that render with the built-in templates
+func newBatchOptions(t *testing.T) batchOptions {
+	t.Helper()
+	templates, err := wizard.LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return batchOptions{Templates: templates}
+}
+
+func TestProcessRepo(t *testing.T) {
+	repo := newGoRepo(t, newMemRoot(t), "svc")
+	cmd := newAnswerCommand(t, "--github-action")
+	opts := newBatchOptions(t)
+
+	// Dry run reports what would be created without writing
+	dryRun := opts
+	dryRun.DryRun = true
+	result := processRepo(cmd, repo, dryRun)
+	if result.Status != batchCreated || len(result.Files) != 2 {
+		t.Fatalf("Dry run = %+v, want created with 2 files", result)
+	}
+	if repo.Exists(wizard.ConfigFile) {
+		t.Fatal("Dry run wrote files")
+	}
+
+	result = processRepo(cmd, repo, opts)
+	if result.Status != batchCreated || !repo.Exists(wizard.WorkflowFile) {
+		t.Fatalf("First run = %+v, want created", result)
+	}
+
+	result = processRepo(cmd, repo, opts)
+	if result.Status != batchSkipped || result.Detail != "up to date" {
+		t.Errorf("Second run = %+v, want skipped as up to date", result)
+	}
+
+	// Edited files are reported with a diff but only replaced with --force
+	edited := []byte("# edited\n")
+	if err := repo.WriteFile(wizard.ConfigFile, edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+	result = processRepo(cmd, repo, opts)
+	if result.Status != batchSkipped || !strings.Contains(result.Diff, "-# edited") {
+		t.Errorf("Edited run = %+v, want skipped with diff", result)
+	}
+	if data, _ := repo.ReadFile(wizard.ConfigFile); !bytes.Equal(data, edited) {
+		t.Error("Edited file overwritten without --force")
+	}
+
+	force := opts
+	force.Force = true
+	result = processRepo(cmd, repo, force)
+	if result.Status != batchChanged || !slices.Equal(result.Files, []string{wizard.ConfigFile}) {
+		t.Errorf("Forced run = %+v, want changed %s", result, wizard.ConfigFile)
+	}
+}
+
+func TestProcessRepoIsolatesFailures(t *testing.T) {
+	dir := newMemRoot(t)
+	cmd := newAnswerCommand(t, "--docker")
+	opts := newBatchOptions(t)
+
+	// Missing directory and missing answers fail the repository, not the batch
+	missing := wizard.Root{Dir: dir.Path("missing"), FS: dir.FS}
+	if result := processRepo(cmd, missing, opts); result.Status != batchFailed {
+		t.Errorf("Missing repo = %+v, want failed", result)
+	}
+	result := processRepo(cmd, newGoRepo(t, dir, "svc"), opts)
+	if result.Status != batchFailed || !strings.Contains(result.Detail, "--registry") {
+		t.Errorf("Repo without registry = %+v, want failed naming --registry", result)
+	}
+
+	if err := dir.MkdirAll("docs"); err != nil {
+		t.Fatal(err)
+	}
+	docs := wizard.Root{Dir: dir.Path("docs"), FS: dir.FS}
+	if result := processRepo(cmd, docs, opts); result.Status != batchSkipped {
+		t.Errorf("Non-Go directory = %+v, want skipped", result)
+	}
+}
+
+func TestBatchRepos(t *testing.T) {
+	dir := newMemRoot(t)
+	newGoRepo(t, dir, "a")
+	newGoRepo(t, dir, "b")
+	if err := dir.WriteFile("notes.txt", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := projectRoot
+	projectRoot = dir
+	t.Cleanup(func() { projectRoot = saved })
+
+	repos, err := batchRepos([]string{"*", "a", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{dir.Path("a"), dir.Path("b"), dir.Path("c")}
+	if !slices.Equal(repos, want) {
+		t.Errorf("batchRepos() = %v, want %v", repos, want)
+	}
+	if root := repoRoot(repos[0]); root.FS != dir.FS || !root.Exists("go.mod") {
+		t.Errorf("repoRoot() = %+v, want a repository on the --dir filesystem", root)
+	}
+
+	if _, err := batchRepos([]string{"nothing-*"}); err == nil {
+		t.Error("Expected an error for a glob matching no directories")
+	}
+}
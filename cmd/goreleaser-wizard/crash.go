@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// crashConfig is the configuration being built by the running command,
+// included (redacted) in crash reports
+var crashConfig *wizard.ProjectConfig
+
+const issuesURL = "https://github.com/LarsArtmann/template-GoReleaser/issues"
+
+// secretPatterns match credentials that may appear in hooks, registries or
+// the command line; the first and second groups are kept around the mask
+var secretPatterns = []*regexp.Regexp{
+	// user:password@ in URLs
+	regexp.MustCompile(`(://)[^/\s:@]+:[^/\s@]+(@)`),
+	// token=..., PASSWORD: ...
+	regexp.MustCompile(`(?i)((?:token|secret|password|passwd|api[_-]?key)\w*["']?\s*[=:]\s*["']?)[^\s"',]+`),
+	// --api-key value
+	regexp.MustCompile(`(?i)(--[\w-]*(?:token|secret|password|key)[\w-]*\s+)[^\s-][^\s]*`),
+	// Authorization: Bearer ...
+	regexp.MustCompile(`(?i)((?:bearer|basic)\s+)[^\s"']+`),
+	// Well-known token prefixes (GitHub, GitLab, Slack)
+	regexp.MustCompile(`()\b(?:gh[pousr]_|github_pat_|glpat-|xox[abpr]-)[A-Za-z0-9_-]+`),
+}
+
+// redactSecrets masks credentials in s
+func redactSecrets(s string) string {
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, "${1}***${2}")
+	}
+	return s
+}
+
+// crashReport builds the redacted text of a crash report
+func crashReport(context string, r any, stack []byte, config *wizard.ProjectConfig) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "GoReleaser Wizard crash report")
+	fmt.Fprintf(&b, "Time: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Context: %s\n", context)
+	fmt.Fprintf(&b, "Panic: %v\n", r)
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "Version: %s\n", version)
+	fmt.Fprintf(&b, "Git Commit: %s\n", commit)
+	fmt.Fprintf(&b, "Build Date: %s\n", date)
+	fmt.Fprintf(&b, "Built By: %s\n", builtBy)
+	fmt.Fprintf(&b, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Command: %s\n", strings.Join(os.Args, " "))
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "Project configuration:")
+	if config == nil {
+		fmt.Fprintln(&b, "  (none)")
+	} else if data, err := yaml.Marshal(config); err != nil {
+		fmt.Fprintf(&b, "  (unavailable: %v)\n", err)
+	} else {
+		b.Write(data)
+	}
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "Stack:")
+	b.Write(stack)
+
+	return redactSecrets(b.String())
+}
+
+// writeCrashReport saves the report to a private file in the temp directory
+func writeCrashReport(report string) (string, error) {
+	f, err := os.CreateTemp("", "goreleaser-wizard-crash-*.log")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(report); err != nil {
+		return f.Name(), err
+	}
+	return f.Name(), nil
+}
+
+// ReportPanic writes a crash report for a recovered panic, points the user
+// to it and exits with ExitFailure
+func ReportPanic(context string, r any, stack []byte, logger *log.Logger) {
+	report := crashReport(context, r, stack, crashConfig)
+	path, err := writeCrashReport(report)
+
+	if viper.GetString("output") == "json" {
+		out := ErrorJSON{
+			Code:       "panic",
+			ExitCode:   ExitFailure,
+			Message:    "Unexpected error",
+			Details:    redactSecrets(fmt.Sprint(r)),
+			Suggestion: "Please report this issue at " + issuesURL,
+		}
+		if err == nil {
+			out.Suggestion += " and attach " + path
+		}
+		if data, jsonErr := json.Marshal(out); jsonErr == nil {
+			fmt.Fprintln(os.Stderr, string(data))
+		}
+	} else {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, errorStyle.Stderr("💥 Unexpected error occurred!"))
+		fmt.Fprintln(os.Stderr, infoStyle.Stderr("The wizard encountered an unexpected problem and had to stop."))
+		fmt.Fprintln(os.Stderr)
+		if err == nil {
+			fmt.Fprintln(os.Stderr, "Crash report written to:")
+			fmt.Fprintln(os.Stderr, "   "+path)
+			fmt.Fprintln(os.Stderr)
+			fmt.Fprintln(os.Stderr, suggestStyle.Stderr("💡 Please report this issue and attach the crash report at:"))
+		} else {
+			// Without a file the report goes to the console instead
+			fmt.Fprintln(os.Stderr, report)
+			fmt.Fprintln(os.Stderr, suggestStyle.Stderr("💡 Please report this issue with the output above at:"))
+		}
+		fmt.Fprintln(os.Stderr, "   "+issuesURL)
+	}
+
+	if logger != nil {
+		logger.Debug("Panic recovered", "context", context, "panic", redactSecrets(fmt.Sprint(r)), "report", path, "error", err)
+	}
+
+	os.Exit(ExitFailure)
+}
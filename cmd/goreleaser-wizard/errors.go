@@ -1,14 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
 	"github.com/charmbracelet/log"
+	"github.com/spf13/viper"
 )
 
 // Custom error types for better error handling
@@ -24,8 +28,111 @@ var (
 	ErrConfiguration     = errors.New("configuration error")
 	ErrUserInput         = errors.New("user input error")
 	ErrFileOperation     = errors.New("file operation error")
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrCancelled         = errors.New("cancelled")
+	ErrBatchFailed       = errors.New("batch failed")
 )
 
+// Exit codes are part of the CLI contract; scripts may rely on them
+const (
+	ExitOK         = 0
+	ExitFailure    = 1   // unexpected errors without a WizardError type
+	ExitUsage      = 2   // invalid flags, arguments or answers
+	ExitConfig     = 3   // configuration problems, including existing files
+	ExitNotFound   = 4   // project files not found
+	ExitFileIO     = 5   // reading or writing files failed
+	ExitPermission = 6   // permission denied
+	ExitDependency = 7   // a required tool is missing
+	ExitTemplate   = 8   // template rendering failed
+	ExitValidation = 9   // validate reported error-level findings
+	ExitBatch      = 10  // batch failed for one or more repositories
+	ExitCancelled  = 130 // interrupted by the user
+)
+
+// exitCodes maps WizardError types to exit codes
+var exitCodes = map[error]int{
+	ErrInvalidInput:      ExitUsage,
+	ErrUserInput:         ExitUsage,
+	ErrConfiguration:     ExitConfig,
+	ErrConfigExists:      ExitConfig,
+	ErrProjectNotFound:   ExitNotFound,
+	ErrFileRead:          ExitFileIO,
+	ErrFileWrite:         ExitFileIO,
+	ErrFileOperation:     ExitFileIO,
+	ErrPermission:        ExitPermission,
+	ErrDependency:        ExitDependency,
+	ErrTemplateExecution: ExitTemplate,
+	ErrValidationFailed:  ExitValidation,
+	ErrCancelled:         ExitCancelled,
+	ErrBatchFailed:       ExitBatch,
+}
+
+// errorCodes gives each WizardError type a stable identifier for JSON output
+var errorCodes = map[error]string{
+	ErrConfigExists:      "config_exists",
+	ErrProjectNotFound:   "project_not_found",
+	ErrInvalidInput:      "invalid_input",
+	ErrTemplateExecution: "template_execution",
+	ErrFileWrite:         "file_write",
+	ErrFileRead:          "file_read",
+	ErrPermission:        "permission_denied",
+	ErrDependency:        "missing_dependency",
+	ErrConfiguration:     "configuration",
+	ErrUserInput:         "user_input",
+	ErrFileOperation:     "file_operation",
+	ErrValidationFailed:  "validation_failed",
+	ErrCancelled:         "cancelled",
+	ErrBatchFailed:       "batch_failed",
+}
+
+// ErrorJSON is the --output json form of an error
+type ErrorJSON struct {
+	Code       string   `json:"code"`
+	ExitCode   int      `json:"exitCode"`
+	Message    string   `json:"message"`
+	Details    string   `json:"details,omitempty"`
+	Suggestion string   `json:"suggestion,omitempty"`
+	Causes     []string `json:"causes,omitempty"`
+}
+
+// NewErrorJSON converts err, walking its cause chain
+func NewErrorJSON(err error) ErrorJSON {
+	out := ErrorJSON{Code: "unexpected", ExitCode: ExitCode(err), Message: err.Error()}
+
+	cause := errors.Unwrap(err)
+	var wizErr *WizardError
+	if errors.As(err, &wizErr) {
+		if code, ok := errorCodes[wizErr.Type]; ok {
+			out.Code = code
+		}
+		out.Message = wizErr.Message
+		out.Details = wizErr.Details
+		out.Suggestion = wizErr.Suggestion
+		cause = wizErr.Err
+	} else {
+		out.Suggestion = getSuggestionForError(err)
+	}
+
+	for ; cause != nil; cause = errors.Unwrap(cause) {
+		out.Causes = append(out.Causes, cause.Error())
+	}
+	return out
+}
+
+// ExitCode returns the process exit code for err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var wizErr *WizardError
+	if errors.As(err, &wizErr) {
+		if code, ok := exitCodes[wizErr.Type]; ok {
+			return code
+		}
+	}
+	return ExitFailure
+}
+
 // WizardError provides detailed error information with recovery suggestions
 type WizardError struct {
 	Type       error
@@ -60,22 +167,28 @@ func HandleError(err error, logger *log.Logger) {
 		return
 	}
 
+	// Automation gets one JSON object per error instead of styled text
+	if viper.GetString("output") == "json" {
+		data, jsonErr := json.Marshal(NewErrorJSON(err))
+		if jsonErr == nil {
+			fmt.Fprintln(os.Stderr, string(data))
+			return
+		}
+	}
+
 	// Check if it's a WizardError with details
 	var wizErr *WizardError
 	if errors.As(err, &wizErr) {
 		// Display structured error information
-		fmt.Println()
-		fmt.Println(errorStyle.Render("❌ Error: " + wizErr.Message))
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, errorStyle.Stderr("❌ Error: " + wizErr.Message))
 		
 		if wizErr.Details != "" {
-			fmt.Println(infoStyle.Render("Details: " + wizErr.Details))
+			fmt.Fprintln(os.Stderr, infoStyle.Stderr("Details: " + wizErr.Details))
 		}
 		
 		if wizErr.Suggestion != "" {
-			suggestStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("220")).
-				Bold(true)
-			fmt.Println(suggestStyle.Render("💡 Suggestion: " + wizErr.Suggestion))
+			fmt.Fprintln(os.Stderr, suggestStyle.Stderr("💡 Suggestion: " + wizErr.Suggestion))
 		}
 		
 		// Log the full error for debugging
@@ -88,16 +201,13 @@ func HandleError(err error, logger *log.Logger) {
 		}
 	} else {
 		// Generic error handling
-		fmt.Println()
-		fmt.Println(errorStyle.Render("❌ Error: " + err.Error()))
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, errorStyle.Stderr("❌ Error: " + err.Error()))
 		
 		// Provide generic suggestions based on error content
 		suggestion := getSuggestionForError(err)
 		if suggestion != "" {
-			suggestStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("220")).
-				Bold(true)
-			fmt.Println(suggestStyle.Render("💡 Suggestion: " + suggestion))
+			fmt.Fprintln(os.Stderr, suggestStyle.Stderr("💡 Suggestion: " + suggestion))
 		}
 		
 		if logger != nil {
@@ -128,126 +238,20 @@ func getSuggestionForError(err error) string {
 	}
 }
 
-// RecoverFromPanic provides graceful panic recovery
-func RecoverFromPanic(logger *log.Logger) {
-	if r := recover(); r != nil {
-		fmt.Println()
-		fmt.Println(errorStyle.Render("💥 Unexpected error occurred!"))
-		fmt.Println(infoStyle.Render("The wizard encountered an unexpected problem and had to stop."))
-		
-		suggestStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("220")).
-			Bold(true)
-		fmt.Println(suggestStyle.Render("💡 Please report this issue at:"))
-		fmt.Println("   https://github.com/LarsArtmann/template-GoReleaser/issues")
-		fmt.Println()
-		fmt.Println("Include this information:")
-		fmt.Printf("   Error: %v\n", r)
-		
-		if logger != nil {
-			logger.Fatal("Panic recovered", "panic", r)
-		}
-		
-		os.Exit(1)
-	}
-}
-
-// ValidateFilePermissions checks if we can write to a directory
-func ValidateFilePermissions(path string) error {
-	// Check if directory exists and is writable
-	if info, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			// Try to create the directory
-			if err := os.MkdirAll(path, 0755); err != nil {
-				return NewWizardError(
-					ErrPermission,
-					"Cannot create directory",
-					fmt.Sprintf("Failed to create %s", path),
-					"Check that you have write permissions in the parent directory",
-					err,
-				)
-			}
-		} else {
-			return NewWizardError(
-				ErrFileRead,
-				"Cannot access directory",
-				fmt.Sprintf("Failed to access %s", path),
-				"Check that the path exists and you have read permissions",
-				err,
-			)
-		}
-	} else if !info.IsDir() {
-		return NewWizardError(
-			ErrInvalidInput,
-			"Path is not a directory",
-			fmt.Sprintf("%s exists but is not a directory", path),
-			"Please specify a valid directory path",
-			nil,
-		)
-	}
-	
-	// Test write permissions by creating a temporary file
-	testFile := fmt.Sprintf("%s/.wizard_test_%d", path, os.Getpid())
-	if f, err := os.Create(testFile); err != nil {
-		return NewWizardError(
-			ErrPermission,
-			"No write permission",
-			fmt.Sprintf("Cannot write to %s", path),
-			"Check that you have write permissions in this directory",
-			err,
-		)
-	} else {
-		f.Close()
-		os.Remove(testFile)
-	}
-	
-	return nil
-}
-
-// SafeFileWrite writes a file with proper error handling and recovery
-func SafeFileWrite(path string, content []byte, perm os.FileMode) error {
-	// Create backup if file exists
-	if fileExists(path) {
-		backupPath := path + ".backup"
-		if data, err := os.ReadFile(path); err == nil {
-			if err := os.WriteFile(backupPath, data, perm); err != nil {
-				log.Debug("Failed to create backup", "file", path, "error", err)
-			}
-		}
-	}
-	
-	// Write the file
-	if err := os.WriteFile(path, content, perm); err != nil {
-		return NewWizardError(
-			ErrFileWrite,
-			"Failed to write file",
-			fmt.Sprintf("Could not write to %s", path),
-			"Check file permissions and disk space",
-			err,
-		)
-	}
-	
-	return nil
-}
-
-// fileExists checks if a file exists
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
-
-// HandlePanic provides standardized panic recovery
+// HandlePanic recovers a panic, writes a crash report and exits non-zero.
+// It must be deferred directly so recover() sees the panic.
 func HandlePanic(context string, logger *log.Logger) {
 	if r := recover(); r != nil {
-		RecoverFromPanic(logger)
+		ReportPanic(context, r, debug.Stack(), logger)
 	}
 }
 
-// CheckFileExists checks if a file exists and is accessible
-func CheckFileExists(path string, requireDir bool) error {
-	info, err := os.Stat(path)
+// CheckFileExists checks if a file in root exists and is accessible
+func CheckFileExists(root wizard.Root, name string, requireDir bool) error {
+	path := root.Path(name)
+	info, err := root.Stat(name)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return NewWizardError(
 				ErrProjectNotFound,
 				"File not found",
@@ -307,9 +311,27 @@ func TemplateError(templateName string, err error) *WizardError {
 	)
 }
 
-// SafeReadFile reads a file safely with error handling
-func SafeReadFile(path string) ([]byte, error) {
-	data, err := os.ReadFile(path)
+// MissingAnswersError reports every required answer that could not be filled
+// without prompting, with the flag that provides each one
+func MissingAnswersError(missing []MissingAnswer) *WizardError {
+	var lines, flags []string
+	for _, answer := range missing {
+		lines = append(lines, fmt.Sprintf("%s (%s)", answer.Question, answer.Flag))
+		flags = append(flags, answer.Flag)
+	}
+	return NewWizardError(
+		ErrUserInput,
+		fmt.Sprintf("%d required answer(s) missing", len(missing)),
+		"Missing: "+strings.Join(lines, ", "),
+		fmt.Sprintf("Pass %s, set them in the config file or GORELEASER_WIZARD_* environment variables, or run in a terminal", strings.Join(flags, ", ")),
+		nil,
+	)
+}
+
+// SafeReadFile reads a file in root safely with error handling
+func SafeReadFile(root wizard.Root, name string) ([]byte, error) {
+	path := root.Path(name)
+	data, err := root.ReadFile(name)
 	if err != nil {
 		return nil, NewWizardError(
 			ErrFileRead,
@@ -322,31 +344,35 @@ func SafeReadFile(path string) ([]byte, error) {
 	return data, nil
 }
 
-// SafeCreateFile creates a file safely with error handling
-func SafeCreateFile(path string) (*os.File, error) {
+// SafeWriteFile writes a file for run w safely with error handling,
+// creating its directory first. The write is atomic and a replaced file is
+// backed up for 'restore'.
+func SafeWriteFile(w *wizard.Writer, name string, content []byte) error {
+	root := w.Root()
+
 	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, NewWizardError(
-			ErrPermission,
-			"Cannot create directory",
-			fmt.Sprintf("Failed to create directory %s", dir),
-			"Check directory permissions",
-			err,
-		)
+	if dir := filepath.Dir(name); dir != "." {
+		if err := root.MkdirAll(dir); err != nil {
+			return NewWizardError(
+				ErrPermission,
+				"Cannot create directory",
+				fmt.Sprintf("Failed to create directory %s", root.Path(dir)),
+				"Check directory permissions",
+				err,
+			)
+		}
 	}
 	
-	file, err := os.Create(path)
-	if err != nil {
-		return nil, NewWizardError(
+	if err := w.WriteFile(name, content, 0644); err != nil {
+		return NewWizardError(
 			ErrFileWrite,
-			"Cannot create file",
-			fmt.Sprintf("Failed to create %s", path),
+			"Cannot write file",
+			fmt.Sprintf("Failed to write %s", root.Path(name)),
 			"Check file permissions and disk space",
 			err,
 		)
 	}
-	return file, nil
+	return nil
 }
 
 // WrapFileError wraps file operation errors
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/charmbracelet/huh"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain_error", errors.New("boom"), ExitFailure},
+		{"user_input", UserInputError("name", errors.New("empty")), ExitUsage},
+		{"missing_answers", MissingAnswersError([]MissingAnswer{{"Project name", "--name"}}), ExitUsage},
+		{"config_exists", NewWizardError(ErrConfigExists, "exists", "", "", nil), ExitConfig},
+		{"configuration", NewWizardError(ErrConfiguration, "bad", "", "", nil), ExitConfig},
+		{"permission", NewWizardError(ErrPermission, "denied", "", "", nil), ExitPermission},
+		{"dependency", NewWizardError(ErrDependency, "missing", "", "", nil), ExitDependency},
+		{"template", TemplateError("x", errors.New("bad")), ExitTemplate},
+		{"validation", NewWizardError(ErrValidationFailed, "failed", "", "", nil), ExitValidation},
+		{"batch", NewWizardError(ErrBatchFailed, "failed", "", "", nil), ExitBatch},
+		{"wrapped", fmt.Errorf("context: %w", NewWizardError(ErrFileWrite, "write", "", "", nil)), ExitFileIO},
+		{"form_aborted", formError("review", huh.ErrUserAborted), ExitCancelled},
+		{"form_invalid", formError("review", errors.New("bad")), ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodesCoverErrorTypes(t *testing.T) {
+	for _, errType := range []error{
+		ErrConfigExists, ErrProjectNotFound, ErrInvalidInput, ErrTemplateExecution,
+		ErrFileWrite, ErrFileRead, ErrPermission, ErrDependency, ErrConfiguration,
+		ErrUserInput, ErrFileOperation, ErrValidationFailed, ErrCancelled, ErrBatchFailed,
+	} {
+		if _, ok := exitCodes[errType]; !ok {
+			t.Errorf("No exit code for %q", errType)
+		}
+	}
+}
+
+func TestNewErrorJSON(t *testing.T) {
+	root := errors.New("disk full")
+	err := NewWizardError(ErrFileWrite, "Failed to write file", "Could not write to LICENSE", "Check disk space", fmt.Errorf("write LICENSE: %w", root))
+
+	got := NewErrorJSON(fmt.Errorf("license: %w", err))
+	want := ErrorJSON{
+		Code:       "file_write",
+		ExitCode:   ExitFileIO,
+		Message:    "Failed to write file",
+		Details:    "Could not write to LICENSE",
+		Suggestion: "Check disk space",
+		Causes:     []string{"write LICENSE: disk full", "disk full"},
+	}
+	if got.Code != want.Code || got.ExitCode != want.ExitCode || got.Message != want.Message ||
+		got.Details != want.Details || got.Suggestion != want.Suggestion || !slices.Equal(got.Causes, want.Causes) {
+		t.Errorf("NewErrorJSON() = %+v, want %+v", got, want)
+	}
+
+	plain := NewErrorJSON(errors.New("permission denied"))
+	if plain.Code != "unexpected" || plain.ExitCode != ExitFailure || plain.Suggestion == "" {
+		t.Errorf("NewErrorJSON(plain) = %+v", plain)
+	}
+}
+
+func TestErrorCodesCoverErrorTypes(t *testing.T) {
+	for errType := range exitCodes {
+		if errorCodes[errType] == "" {
+			t.Errorf("No error code for %q", errType)
+		}
+	}
+}
+
+func TestSafeWriteFile(t *testing.T) {
+	root := newMemRoot(t)
+	w := wizard.NewWriter(root)
+	if err := SafeWriteFile(w, wizard.WorkflowFile, []byte("name: Release\n")); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := root.ReadFile(wizard.WorkflowFile); err != nil || string(data) != "name: Release\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+
+	if backup := w.Backup(); backup == nil || !slices.Equal(backup.Created, []string{wizard.WorkflowFile}) {
+		t.Errorf("Backup() = %+v, want %s recorded as created", backup, wizard.WorkflowFile)
+	}
+
+	// A file in the way of the directory is a permission-type error; the
+	// in-memory filesystem does not detect it
+	disk := wizard.NewWriter(wizard.NewRoot(t.TempDir()))
+	if err := SafeWriteFile(disk, "LICENSE", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := SafeWriteFile(disk, filepath.Join("LICENSE", "nested"), nil); ExitCode(err) != exitCodes[ErrPermission] {
+		t.Errorf("SafeWriteFile() under a file = %v, want ErrPermission", err)
+	}
+}
@@ -1,73 +1,17 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var (
-	// Style definitions
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("99")).
-			MarginBottom(1)
-
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42")).
-			Bold(true)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	infoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86"))
-)
-
-// ProjectConfig holds all the configuration options
-type ProjectConfig struct {
-	// Basic Info
-	ProjectName        string
-	ProjectDescription string
-	ProjectType        string
-	BinaryName         string
-	MainPath           string
-
-	// Build Options
-	Platforms      []string
-	Architectures  []string
-	CGOEnabled     bool
-	BuildTags      []string
-	LDFlags        bool
-
-	// Release Options
-	GitProvider    string
-	DockerEnabled  bool
-	DockerRegistry string
-	Signing        bool
-	Homebrew       bool
-	Snap           bool
-	SBOM           bool
-
-	// GitHub Actions
-	GenerateActions bool
-	ActionsOn       []string
-
-	// Advanced
-	ProVersion     bool
-	Compression    string
-	Hooks          []string
-	SkipValidation bool
-}
-
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Interactive wizard to create GoReleaser configuration",
@@ -80,21 +24,22 @@ It will:
 - Generate optimized .goreleaser.yaml
 - Optionally create GitHub Actions workflow
 - Apply best practices automatically`,
-	Run: runInitWizard,
+	RunE: runInitWizard,
 }
 
+// accessibleMode switches every form to huh's line-by-line accessible mode
+var accessibleMode bool
+
 func init() {
 	initCmd.Flags().Bool("force", false, "overwrite existing configuration")
-	initCmd.Flags().Bool("minimal", false, "create minimal configuration")
-	initCmd.Flags().Bool("pro", false, "use GoReleaser Pro features")
+	initCmd.Flags().Bool("non-interactive", false, "skip the forms and answer from detection, config and flags")
+	initCmd.Flags().Bool("accessible", false, "ask questions line by line (screen readers, dumb terminals, piped answers)")
+	addAnswerFlags(initCmd.Flags())
 }
 
-func runInitWizard(cmd *cobra.Command, args []string) {
+func runInitWizard(cmd *cobra.Command, args []string) error {
 	// Set up logger
-	logger := log.New(os.Stderr)
-	if viper.GetBool("debug") {
-		logger.SetLevel(log.DebugLevel)
-	}
+	logger := newLogger()
 
 	// Set up panic recovery
 	defer HandlePanic("init wizard", logger)
@@ -105,62 +50,90 @@ func runInitWizard(cmd *cobra.Command, args []string) {
 	// Check if config already exists
 	force, _ := cmd.Flags().GetBool("force")
 	if !force {
-		if err := CheckFileExists(".goreleaser.yaml", false); err == nil {
+		if err := CheckFileExists(projectRoot, wizard.ConfigFile, false); err == nil {
 			// File exists and is accessible
-			logger.Warn("Configuration already exists", "file", ".goreleaser.yaml")
+			logger.Warn("Configuration already exists", "file", projectRoot.Path(wizard.ConfigFile))
 			err := NewWizardError(
-				ErrConfiguration,
+				ErrConfigExists,
 				".goreleaser.yaml already exists",
-				"Configuration file found in current directory",
+				"Configuration file found in the project directory",
 				"Use --force to overwrite existing configuration",
 				nil,
 			)
-			LogAndDisplayError(err, logger)
-			return
+			return err
 		}
 	}
 
-	config := &ProjectConfig{}
-
-	// Detect project info
-	detectProjectInfo(config)
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+	nonInteractive = nonInteractive || viper.GetBool("non-interactive")
+	accessibleMode, _ = cmd.Flags().GetBool("accessible")
+	accessibleMode = accessibleMode || viper.GetBool("accessible")
 
-	// Run interactive forms with enhanced error handling
-	if err := askBasicInfo(config); err != nil {
-		LogAndDisplayError(UserInputError("basic information", err), logger)
-		return
+	// Without a terminal the forms cannot render, so answer from flags instead
+	if !nonInteractive && !accessibleMode && !isInteractive() {
+		logger.Info("No terminal detected, running non-interactively (use --accessible to answer line by line)")
+		nonInteractive = true
 	}
 
-	if err := askBuildOptions(config); err != nil {
-		LogAndDisplayError(UserInputError("build options", err), logger)
-		return
-	}
+	config := &wizard.ProjectConfig{}
+	crashConfig = config
 
-	if err := askReleaseOptions(config); err != nil {
-		LogAndDisplayError(UserInputError("release options", err), logger)
-		return
+	// Detect project info, then let flags, config and environment override it
+	if err := detectProjectInfo(projectRoot, config); err != nil {
+		return err
 	}
+	askPro := !cmd.Flags().Changed("pro") && !viper.IsSet("pro")
+	if err := applyAnswerFlags(cmd, config); err != nil {
+		return err
+	}
+	pack, err := loadPack()
+	if err != nil {
+		return err
+	}
+	if nonInteractive {
+		if config.BinaryName == "" {
+			config.BinaryName = config.ProjectName
+		}
+		if missing := missingAnswers(config); len(missing) > 0 {
+			return MissingAnswersError(missing)
+		}
+		if err := checkPackAnswers(pack, config); err != nil {
+			return err
+		}
+	} else {
+		if pack != nil {
+			pack.ApplyDefaults(config)
+		}
+		// Run interactive forms with enhanced error handling
+		sections := wizardSections(askPro)
+		if pack != nil && len(pack.Manifest.Questions) > 0 {
+			sections = append(sections, packSection(pack))
+		}
+		for _, section := range sections {
+			if !section.Enabled(config) {
+				continue
+			}
+			if err := section.Ask(config); err != nil {
+				return formError(strings.ToLower(section.Title), err)
+			}
+		}
 
-	if err := askAdvancedOptions(config); err != nil {
-		LogAndDisplayError(UserInputError("advanced options", err), logger)
-		return
+		// Review answers before anything is written
+		confirmed, err := reviewConfig(config, sections)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println(infoStyle.Render("Cancelled, no files were written."))
+			return nil
+		}
 	}
 
 	// Generate configuration
 	fmt.Println("\n" + infoStyle.Render("Generating configuration..."))
 
-	if err := generateGoReleaserConfig(config); err != nil {
-		LogAndDisplayError(TemplateError("goreleaser.yaml", err), logger)
-		return
-	}
-	fmt.Println(successStyle.Render("✓ Created .goreleaser.yaml"))
-
-	if config.GenerateActions {
-		if err := generateGitHubActions(config); err != nil {
-			LogAndDisplayError(TemplateError("github actions workflow", err), logger)
-			return
-		}
-		fmt.Println(successStyle.Render("✓ Created .github/workflows/release.yml"))
+	if err := writeProjectFiles(config, force); err != nil {
+		return err
 	}
 
 	// Show next steps
@@ -171,56 +144,98 @@ func runInitWizard(cmd *cobra.Command, args []string) {
 	fmt.Println("  3. Test with 'goreleaser build --snapshot --clean'")
 	fmt.Println("  4. Create a git tag and push to trigger release")
 	fmt.Println("\nFor more info: https://goreleaser.com")
+	return nil
 }
 
-func detectProjectInfo(config *ProjectConfig) {
-	// Try to detect project name from go.mod with error handling
-	data, err := SafeReadFile("go.mod")
-	if err == nil {
-		lines := strings.Split(string(data), "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "module ") {
-				module := strings.TrimPrefix(line, "module ")
-				parts := strings.Split(module, "/")
-				if len(parts) > 0 {
-					config.ProjectName = parts[len(parts)-1]
-				}
-				break
-			}
+// detectProjectInfo fills config with what wizard.Detect finds in the
+// project root, keeping a binary name or license already answered
+func detectProjectInfo(root wizard.Root, config *wizard.ProjectConfig) error {
+	detected, err := root.Detect()
+	if err != nil {
+		return NewWizardError(
+			ErrProjectNotFound,
+			"Cannot read project directory",
+			err.Error(),
+			"Run the wizard from your project root or pass --dir",
+			err,
+		)
+	}
+
+	if detected.ProjectName != "" {
+		config.ProjectName = detected.ProjectName
+	}
+	if detected.MainPath != "" {
+		config.MainPath = detected.MainPath
+		config.ProjectType = detected.ProjectType
+	}
+	if config.BinaryName == "" {
+		config.BinaryName = detected.BinaryName
+	}
+	if config.License == "" {
+		config.License = detected.License
+	}
+	return nil
+}
+
+// formError wraps a form failure, telling an aborted form (Ctrl-C, Esc)
+// apart from bad input
+func formError(field string, err error) *WizardError {
+	if errors.Is(err, huh.ErrUserAborted) {
+		return NewWizardError(ErrCancelled, "Wizard cancelled", "No files were written", "Run the wizard again when ready", err)
+	}
+	return UserInputError(field, err)
+}
+
+// newForm builds a form that honours accessible mode
+func newForm(groups ...*huh.Group) *huh.Form {
+	return huh.NewForm(groups...).WithAccessible(accessibleMode)
+}
+
+// splitList splits comma- or newline-separated input into trimmed, non-empty items
+func splitList(input string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
+	return items
+}
 
-	// Detect main.go location with error handling
-	if CheckFileExists("main.go", false) == nil {
-		config.MainPath = "."
-		config.ProjectType = "CLI Application"
-	} else if config.ProjectName != "" && CheckFileExists("cmd/"+config.ProjectName+"/main.go", false) == nil {
-		config.MainPath = "./cmd/" + config.ProjectName
-		config.ProjectType = "CLI Application"
-	} else {
-		// Look for any main.go in cmd/ with error handling
-		if entries, err := os.ReadDir("cmd"); err == nil {
-			for _, entry := range entries {
-				if entry.IsDir() {
-					mainPath := filepath.Join("cmd", entry.Name(), "main.go")
-					if CheckFileExists(mainPath, false) == nil {
-						config.MainPath = "./cmd/" + entry.Name()
-						config.BinaryName = entry.Name()
-						config.ProjectType = "CLI Application"
-						break
-					}
-				}
-			}
+// applyHookAnswers sets the hooks from the text areas. Hooks are split on
+// newlines only, since commands may contain commas.
+func applyHookAnswers(config *wizard.ProjectConfig, beforeHooks, postBuildHooks string) {
+	config.Hooks = splitLines(beforeHooks)
+	config.PostBuildHooks = splitLines(postBuildHooks)
+}
+
+// splitLines splits newline-separated input into trimmed, non-empty items
+func splitLines(input string) []string {
+	var items []string
+	for _, item := range strings.Split(input, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
+	return items
+}
 
-	// Default binary name
-	if config.BinaryName == "" && config.ProjectName != "" {
-		config.BinaryName = config.ProjectName
+// normalizeMonorepo fills in the monorepo directory and tag prefix from each other
+func normalizeMonorepo(config *wizard.ProjectConfig) {
+	if config.MonorepoPrefix == "" {
+		return
+	}
+	if !strings.HasSuffix(config.MonorepoPrefix, "/") {
+		config.MonorepoPrefix += "/"
+	}
+	if config.MonorepoDir == "" {
+		config.MonorepoDir = strings.TrimSuffix(config.MonorepoPrefix, "/")
 	}
 }
 
-func askBasicInfo(config *ProjectConfig) error {
+func askBasicInfo(config *wizard.ProjectConfig) error {
 	var projectTypes = []string{
 		"CLI Application",
 		"Web Service",
@@ -228,7 +243,7 @@ func askBasicInfo(config *ProjectConfig) error {
 		"Multiple Binaries",
 	}
 
-	form := huh.NewForm(
+	form := newForm(
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Project Name").
@@ -264,13 +279,20 @@ func askBasicInfo(config *ProjectConfig) error {
 				Description("Path to main.go (e.g., . or ./cmd/app)").
 				Value(&config.MainPath).
 				Placeholder("./cmd/" + config.BinaryName),
+
+			huh.NewInput().
+				Title("License").
+				Description("SPDX identifier used in package metadata").
+				Value(&config.License).
+				Placeholder(wizard.DefaultLicense).
+				Suggestions(availableLicenses()),
 		).Title("Basic Information"),
 	)
 
 	return form.Run()
 }
 
-func askBuildOptions(config *ProjectConfig) error {
+func askBuildOptions(config *wizard.ProjectConfig) error {
 	var (
 		platformOptions = []string{
 			"linux",
@@ -300,10 +322,16 @@ func askBuildOptions(config *ProjectConfig) error {
 	)
 
 	// Set defaults
-	config.Platforms = []string{"linux", "darwin", "windows"}
-	config.Architectures = []string{"amd64", "arm64"}
+	if len(config.Platforms) == 0 {
+		config.Platforms = []string{"linux", "darwin", "windows"}
+	}
+	if len(config.Architectures) == 0 {
+		config.Architectures = []string{"amd64", "arm64"}
+	}
+
+	buildTags := strings.Join(config.BuildTags, ", ")
 
-	form := huh.NewForm(
+	form := newForm(
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Target Platforms").
@@ -330,29 +358,139 @@ func askBuildOptions(config *ProjectConfig) error {
 				Value(&config.LDFlags).
 				Affirmative("Yes (recommended)").
 				Negative("No"),
+
+			huh.NewInput().
+				Title("Build Tags").
+				Description("Comma-separated tags (empty uses netgo,osusergo without CGO)").
+				Value(&buildTags).
+				Placeholder("netgo, osusergo"),
 		).Title("Build Options"),
 	)
 
-	return form.Run()
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	config.BuildTags = splitList(buildTags)
+
+	return nil
 }
 
-func askReleaseOptions(config *ProjectConfig) error {
-	gitProviders := []string{
-		"GitHub",
-		"GitLab",
-		"Gitea",
-		"Local Only",
+func askBuildVariants(config *wizard.ProjectConfig) error {
+	configureVariants := len(config.GoARM) > 0 || len(config.GoAMD64) > 0 ||
+		len(config.GoMIPS) > 0 || len(config.BuildOverrides) > 0
+
+	confirmForm := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Architecture Variants & Overrides?").
+				Description("Configure GOARM/GOAMD64/GOMIPS variants and per-target settings").
+				Value(&configureVariants).
+				Affirmative("Yes").
+				Negative("No (defaults)"),
+		),
+	)
+	if err := confirmForm.Run(); err != nil {
+		return err
+	}
+	if !configureVariants {
+		config.GoARM = nil
+		config.GoAMD64 = nil
+		config.GoMIPS = nil
+		config.BuildOverrides = nil
+		return nil
 	}
 
-	config.GitProvider = "GitHub" // default
+	var fields []huh.Field
 
-	form := huh.NewForm(
+	if slices.Contains(config.Architectures, "arm") {
+		if len(config.GoARM) == 0 {
+			config.GoARM = []string{"6", "7"}
+		}
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("GOARM Variants").
+			Description("ARM versions to build for").
+			Options(huh.NewOptions(wizard.GoarmOptions...)...).
+			Value(&config.GoARM))
+	}
+
+	if slices.Contains(config.Architectures, "amd64") {
+		if len(config.GoAMD64) == 0 {
+			config.GoAMD64 = []string{"v1"}
+		}
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("GOAMD64 Variants").
+			Description("x86-64 microarchitecture levels to build for").
+			Options(huh.NewOptions(wizard.Goamd64Options...)...).
+			Value(&config.GoAMD64))
+	}
+
+	if slices.ContainsFunc(config.Architectures, func(arch string) bool {
+		return strings.HasPrefix(arch, "mips")
+	}) {
+		if len(config.GoMIPS) == 0 {
+			config.GoMIPS = []string{"hardfloat"}
+		}
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("GOMIPS Variants").
+			Description("Floating point modes for MIPS builds").
+			Options(huh.NewOptions(wizard.GomipsOptions...)...).
+			Value(&config.GoMIPS))
+	}
+
+	var specs []string
+	for _, override := range config.BuildOverrides {
+		specs = append(specs, override.Spec())
+	}
+	overrideSpecs := strings.Join(specs, "\n")
+
+	fields = append(fields, huh.NewText().
+		Title("Per-Target Overrides").
+		Description("One per line: goos/goarch[/variant]:env=K=V;flags=-x;ldflags=...;tags=...").
+		Placeholder("linux/arm64:env=CGO_ENABLED=1").
+		Value(&overrideSpecs).
+		Validate(func(s string) error {
+			_, err := wizard.ParseBuildOverrides(strings.Split(s, "\n"))
+			return err
+		}))
+
+	form := newForm(
+		huh.NewGroup(fields...).Title("Architecture Variants"),
+	)
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	overrides, err := wizard.ParseBuildOverrides(strings.Split(overrideSpecs, "\n"))
+	if err != nil {
+		return err
+	}
+	config.BuildOverrides = overrides
+
+	return nil
+}
+
+func askReleaseOptions(config *wizard.ProjectConfig) error {
+	if config.GitProvider == "" {
+		config.GitProvider = "GitHub" // default
+	}
+
+	providerSelect := huh.NewSelect[string]().
+		Title("Git Provider").
+		Description("Where is your repository hosted?").
+		Options(huh.NewOptions(gitProviders...)...).
+		Value(&config.GitProvider)
+
+	// Minimal configs only need to know where releases go
+	if config.Minimal {
+		return newForm(
+			huh.NewGroup(providerSelect).Title("Release Options"),
+		).Run()
+	}
+
+	form := newForm(
 		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Git Provider").
-				Description("Where is your repository hosted?").
-				Options(huh.NewOptions(gitProviders...)...).
-				Value(&config.GitProvider),
+			providerSelect,
 
 			huh.NewConfirm().
 				Title("Docker Images?").
@@ -383,7 +521,7 @@ func askReleaseOptions(config *ProjectConfig) error {
 
 	// Ask about Docker registry if Docker is enabled
 	if config.DockerEnabled {
-		registryForm := huh.NewForm(
+		registryForm := newForm(
 			huh.NewGroup(
 				huh.NewInput().
 					Title("Docker Registry").
@@ -399,7 +537,7 @@ func askReleaseOptions(config *ProjectConfig) error {
 
 	// Ask about package managers
 	if config.GitProvider == "GitHub" {
-		pmForm := huh.NewForm(
+		pmForm := newForm(
 			huh.NewGroup(
 				huh.NewConfirm().
 					Title("Homebrew Tap?").
@@ -424,55 +562,101 @@ func askReleaseOptions(config *ProjectConfig) error {
 	return nil
 }
 
-func askAdvancedOptions(config *ProjectConfig) error {
-	compressionOptions := []string{
-		"none",
-		"gzip",
-		"upx (smaller but slower)",
+// askAdvancedOptions asks the advanced questions; askPro is false when
+// --pro already answered the GoReleaser Pro question
+func askAdvancedOptions(config *wizard.ProjectConfig, askPro bool) error {
+	if config.Compression == "" {
+		config.Compression = "gzip" // default
+	}
+
+	runTests := !config.SkipTests
+	beforeHooks := strings.Join(config.Hooks, "\n")
+	postBuildHooks := strings.Join(config.PostBuildHooks, "\n")
+
+	advancedFields := []huh.Field{
+		huh.NewConfirm().
+			Title("Generate GitHub Actions?").
+			Description("Create workflow for automated releases").
+			Value(&config.GenerateActions).
+			Affirmative("Yes (recommended)").
+			Negative("No"),
+
+		huh.NewSelect[string]().
+			Title("Archive Compression").
+			Description("Compression for release archives (none ships raw binaries)").
+			Options(huh.NewOptions(wizard.CompressionOptions...)...).
+			Value(&config.Compression),
+
+		huh.NewConfirm().
+			Title("Compress Binaries with UPX?").
+			Description("Smaller binaries but slower startup (Linux and Windows only)").
+			Value(&config.UPX).
+			Affirmative("Yes").
+			Negative("No"),
 	}
 
-	config.Compression = "gzip" // default
+	if slices.Contains(config.Platforms, "darwin") {
+		advancedFields = append(advancedFields, huh.NewConfirm().
+			Title("Universal macOS Binaries?").
+			Description("Merge darwin amd64 and arm64 into one binary").
+			Value(&config.UniversalBinaries).
+			Affirmative("Yes").
+			Negative("No"))
+	}
+
+	if askPro {
+		advancedFields = append(advancedFields, huh.NewConfirm().
+			Title("GoReleaser Pro?").
+			Description("Use Pro features (requires license)").
+			Value(&config.ProVersion).
+			Affirmative("Yes").
+			Negative("No (free version)"))
+	}
+
+	form := newForm(
+		huh.NewGroup(advancedFields...).Title("Advanced Options"),
 
-	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Generate GitHub Actions?").
-				Description("Create workflow for automated releases").
-				Value(&config.GenerateActions).
+				Title("Run Tests Before Release?").
+				Description("Add 'go test ./...' to the before hooks").
+				Value(&runTests).
 				Affirmative("Yes (recommended)").
 				Negative("No"),
 
-			huh.NewSelect[string]().
-				Title("Archive Compression").
-				Description("Compression for release archives").
-				Options(huh.NewOptions(compressionOptions...)...).
-				Value(&config.Compression),
-
-			huh.NewConfirm().
-				Title("GoReleaser Pro?").
-				Description("Use Pro features (requires license)").
-				Value(&config.ProVersion).
-				Affirmative("Yes").
-				Negative("No (free version)"),
-		).Title("Advanced Options"),
+			huh.NewText().
+				Title("Before Hooks").
+				Description("Extra commands to run before the build, one per line").
+				Placeholder("make generate").
+				Value(&beforeHooks),
+
+			huh.NewText().
+				Title("Post-Build Hooks").
+				Description("Commands to run after each binary is built, one per line").
+				Placeholder("upx {{ .Path }}").
+				Value(&postBuildHooks),
+		).Title("Hooks"),
 	)
 
 	if err := form.Run(); err != nil {
 		return err
 	}
 
+	config.SkipTests = !runTests
+	applyHookAnswers(config, beforeHooks, postBuildHooks)
+
 	// Ask about GitHub Actions triggers if enabled
 	if config.GenerateActions {
-		triggerOptions := []string{
-			"On version tags (v*)",
-			"On all tags",
-			"Manual trigger only",
-			"On push to main",
+		var triggerOptions []string
+		for _, trigger := range actionsTriggers {
+			triggerOptions = append(triggerOptions, trigger.Answer)
 		}
 
-		config.ActionsOn = []string{"On version tags (v*)"}
+		if len(config.ActionsOn) == 0 {
+			config.ActionsOn = []string{"On version tags (v*)"}
+		}
 
-		triggerForm := huh.NewForm(
+		triggerForm := newForm(
 			huh.NewGroup(
 				huh.NewMultiSelect[string]().
 					Title("GitHub Actions Triggers").
@@ -489,3 +673,114 @@ func askAdvancedOptions(config *ProjectConfig) error {
 	return nil
 }
 
+func askProOptions(config *wizard.ProjectConfig) error {
+	nfpmOptions := []string{
+		"deb",
+		"rpm",
+		"apk",
+	}
+
+	var templatedSpecs []string
+	for _, file := range config.TemplatedFiles {
+		templatedSpecs = append(templatedSpecs, file.Src+":"+file.Dst)
+	}
+
+	var (
+		beforePublish  = strings.Join(config.BeforePublish, "\n")
+		templatedFiles = strings.Join(templatedSpecs, "\n")
+		includes       = strings.Join(config.Includes, "\n")
+	)
+
+	fields := []huh.Field{
+		huh.NewMultiSelect[string]().
+			Title("Linux Packages").
+			Description("Build native packages with nFPM (none to skip)").
+			Options(huh.NewOptions(nfpmOptions...)...).
+			Value(&config.NFPMFormats),
+
+		huh.NewConfirm().
+			Title("Changelog Groups?").
+			Description("Group changelog entries by conventional commit type").
+			Value(&config.ChangelogGroups).
+			Affirmative("Yes (recommended)").
+			Negative("No"),
+
+		huh.NewConfirm().
+			Title("Source Archive?").
+			Description("Publish a source tarball alongside binaries").
+			Value(&config.SourceArchive).
+			Affirmative("Yes").
+			Negative("No"),
+	}
+
+	if config.GitProvider == "GitHub" && slices.Contains(config.Platforms, "windows") {
+		fields = append(fields, huh.NewConfirm().
+			Title("Scoop Manifest?").
+			Description("Publish a Scoop manifest for Windows").
+			Value(&config.Scoop).
+			Affirmative("Yes").
+			Negative("No"))
+	}
+
+	form := newForm(
+		huh.NewGroup(fields...).Title("GoReleaser Pro Options"),
+
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Nightly Builds?").
+				Description("Publish a rolling nightly release").
+				Value(&config.Nightly).
+				Affirmative("Yes").
+				Negative("No"),
+
+			huh.NewConfirm().
+				Title("Split/Merge Builds?").
+				Description("Build each GOOS in its own CI job, then merge and publish").
+				Value(&config.Partial).
+				Affirmative("Yes").
+				Negative("No"),
+
+			huh.NewInput().
+				Title("Monorepo Tag Prefix").
+				Description("Only release tags with this prefix (empty for a single project)").
+				Placeholder(config.ProjectName+"/").
+				Value(&config.MonorepoPrefix),
+
+			huh.NewInput().
+				Title("Monorepo Directory").
+				Description("Subdirectory holding this project (empty uses the tag prefix)").
+				Value(&config.MonorepoDir),
+		).Title("Pro Release Flow"),
+
+		huh.NewGroup(
+			huh.NewText().
+				Title("Before Publish Hooks").
+				Description("Commands to run before publishing, one per line").
+				Placeholder("./scripts/notarize.sh").
+				Value(&beforePublish),
+
+			huh.NewText().
+				Title("Templated Extra Files").
+				Description("Release files rendered as templates, one src[:dst] per line").
+				Placeholder("INSTALL.md.tpl:INSTALL.md").
+				Value(&templatedFiles),
+
+			huh.NewText().
+				Title("Includes").
+				Description("Shared config fragments (paths or URLs), one per line").
+				Placeholder("https://example.com/goreleaser/base.yaml").
+				Value(&includes),
+		).Title("Pro Extras"),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	config.BeforePublish = splitLines(beforePublish)
+	config.TemplatedFiles = wizard.ParseTemplatedFiles(splitLines(templatedFiles))
+	config.Includes = splitLines(includes)
+	normalizeMonorepo(config)
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+func TestApplyHookAnswers(t *testing.T) {
+	config := &wizard.ProjectConfig{}
+	applyHookAnswers(config, "go build -tags=a,b ./...\n\n  make generate  \n", `sh -c "x, y"`)
+
+	if want := []string{"go build -tags=a,b ./...", "make generate"}; !slices.Equal(config.Hooks, want) {
+		t.Errorf("Hooks = %q, want %q", config.Hooks, want)
+	}
+	if want := []string{`sh -c "x, y"`}; !slices.Equal(config.PostBuildHooks, want) {
+		t.Errorf("PostBuildHooks = %q, want %q", config.PostBuildHooks, want)
+	}
+}
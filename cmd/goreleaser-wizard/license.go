@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/assets"
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	readmeConfigFile = ".readme/configs/readme-config.yaml"
+	defaultHolder    = "Project Maintainer"
+)
+
+var licenseCmd = &cobra.Command{
+	Use:   "license [SPDX-ID]",
+	Short: "Generate a LICENSE file from built-in templates",
+	Long: `Generate a LICENSE file from the templates embedded in goreleaser-wizard.
+
+The license type is taken from, in order:
+- The SPDX-ID argument
+- The "license" key in the wizard config file
+- .readme/configs/readme-config.yaml (license.type or project.license.type)
+- The LICENSE_TYPE environment variable
+- An existing LICENSE file (detected by SPDX identifier)
+- MIT
+
+The copyright holder comes from --holder, the "copyright-holder" config key,
+the readme config, COPYRIGHT_HOLDER/AUTHOR_NAME/PROJECT_AUTHOR or git user.name.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLicense,
+}
+
+func init() {
+	licenseCmd.Flags().String("holder", "", "copyright holder")
+	licenseCmd.Flags().Int("year", 0, "copyright year (default current year)")
+	licenseCmd.Flags().String("file", "LICENSE", "file to write")
+	licenseCmd.Flags().Bool("list", false, "list available license templates")
+	licenseCmd.Flags().Bool("force", false, "overwrite an existing license file")
+}
+
+func runLicense(cmd *cobra.Command, args []string) error {
+	// Set up logger
+	logger := newLogger()
+
+	// Set up panic recovery
+	defer HandlePanic("license command", logger)
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		fmt.Println(titleStyle.Render("📜 Available Licenses"))
+		for _, id := range availableLicenses() {
+			fmt.Println(plain("  • " + id))
+		}
+		return nil
+	}
+
+	output, _ := cmd.Flags().GetString("file")
+	force, _ := cmd.Flags().GetBool("force")
+
+	existingID, existingFile := projectRoot.DetectLicense()
+	if existingFile != "" && !force {
+		existingFile = projectRoot.Path(existingFile)
+		details := fmt.Sprintf("%s already exists", existingFile)
+		if existingID != "" {
+			details = fmt.Sprintf("%s already contains the %s license", existingFile, existingID)
+		}
+		err := NewWizardError(
+			ErrConfigExists,
+			"License file already exists",
+			details,
+			"Use --force to replace it",
+			nil,
+		)
+		return err
+	}
+
+	var licenseID string
+	if len(args) > 0 {
+		licenseID = args[0]
+	} else {
+		licenseID = resolveLicenseID(existingID)
+	}
+
+	holder, _ := cmd.Flags().GetString("holder")
+	if holder == "" {
+		holder = resolveCopyrightHolder()
+	}
+
+	year, _ := cmd.Flags().GetInt("year")
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	content, err := renderLicense(licenseID, holder, year)
+	if err != nil {
+		return err
+	}
+
+	if err := SafeWriteFile(projectWriter, output, content); err != nil {
+		return err
+	}
+	output = projectRoot.Path(output)
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✓ Created %s (%s)", output, canonicalLicenseID(licenseID))))
+	fmt.Println(infoStyle.Render(fmt.Sprintf("  Copyright %d %s", year, holder)))
+	reportBackup(projectWriter)
+	return nil
+}
+
+// availableLicenses returns the SPDX identifiers of the embedded templates
+func availableLicenses() []string {
+	entries, err := fs.Glob(assets.Licenses, "licenses/*.template")
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, strings.TrimSuffix(path.Base(entry), ".template"))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// canonicalLicenseID matches an identifier case-insensitively against the
+// embedded templates, returning it unchanged when there is no template
+func canonicalLicenseID(id string) string {
+	for _, available := range availableLicenses() {
+		if strings.EqualFold(available, id) {
+			return available
+		}
+	}
+	return id
+}
+
+// renderLicense fills the embedded template for licenseID
+func renderLicense(licenseID, holder string, year int) ([]byte, error) {
+	id := canonicalLicenseID(licenseID)
+	data, err := assets.Licenses.ReadFile("licenses/" + id + ".template")
+	if err != nil {
+		return nil, NewWizardError(
+			ErrInvalidInput,
+			fmt.Sprintf("No license template for %s", licenseID),
+			fmt.Sprintf("Available licenses: %s", strings.Join(availableLicenses(), ", ")),
+			"Run 'goreleaser-wizard license --list' to see supported licenses",
+			err,
+		)
+	}
+
+	content := strings.NewReplacer(
+		"{{YEAR}}", strconv.Itoa(year),
+		"{{COPYRIGHT_HOLDER}}", holder,
+	).Replace(string(data))
+
+	return []byte(content), nil
+}
+
+// resolveLicenseID picks the license type from config, readme config,
+// environment, an already detected license, then the default
+func resolveLicenseID(detected string) string {
+	if id := viper.GetString("license"); id != "" {
+		return id
+	}
+	readme := readReadmeConfig()
+	if id := firstNonEmpty(lookupString(readme, "license", "type"), lookupString(readme, "project", "license", "type")); id != "" {
+		return id
+	}
+	if id := os.Getenv("LICENSE_TYPE"); id != "" {
+		return id
+	}
+	if detected != "" {
+		return detected
+	}
+	return wizard.DefaultLicense
+}
+
+// resolveCopyrightHolder picks the holder from config, readme config,
+// environment and git, falling back to a placeholder
+func resolveCopyrightHolder() string {
+	readme := readReadmeConfig()
+	holder := firstNonEmpty(
+		viper.GetString("copyright-holder"),
+		lookupString(readme, "author", "name"),
+		lookupString(readme, "project", "contact", "name"),
+		lookupString(readme, "project", "author"),
+		os.Getenv("COPYRIGHT_HOLDER"),
+		os.Getenv("AUTHOR_NAME"),
+		os.Getenv("PROJECT_AUTHOR"),
+		gitUserName(),
+	)
+	if holder == "" {
+		return defaultHolder
+	}
+	return holder
+}
+
+// readReadmeConfig loads .readme/configs/readme-config.yaml, returning nil when absent
+func readReadmeConfig() map[string]any {
+	data, err := projectRoot.ReadFile(readmeConfigFile)
+	if err != nil {
+		return nil
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		log.Debug("Invalid readme config", "file", readmeConfigFile, "error", err)
+		return nil
+	}
+	return doc
+}
+
+// lookupString walks nested maps by key and returns the string at the end
+func lookupString(doc map[string]any, keys ...string) string {
+	var current any = doc
+	for _, key := range keys {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return ""
+		}
+		current = m[key]
+	}
+	s, _ := current.(string)
+	return s
+}
+
+// gitUserName returns git's configured user.name for the project, or empty if unavailable
+func gitUserName() string {
+	out, err := projectRoot.Command(context.Background(), "git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+func TestRenderLicense(t *testing.T) {
+	for _, id := range availableLicenses() {
+		t.Run(id, func(t *testing.T) {
+			content, err := renderLicense(id, "Jane Doe", 2024)
+			if err != nil {
+				t.Fatalf("renderLicense() error = %v", err)
+			}
+			text := string(content)
+			if strings.Contains(text, "{{") {
+				t.Error("Rendered license contains unsubstituted placeholders")
+			}
+			if !strings.Contains(text, "2024") || !strings.Contains(text, "Jane Doe") {
+				t.Error("Rendered license missing year or holder")
+			}
+			if got := wizard.IdentifyLicense(text); got != id {
+				t.Errorf("IdentifyLicense(rendered %s) = %q", id, got)
+			}
+		})
+	}
+
+	if _, err := renderLicense("mit", "Jane Doe", 2024); err != nil {
+		t.Errorf("renderLicense() should match SPDX IDs case-insensitively: %v", err)
+	}
+	if _, err := renderLicense("WTFPL", "Jane Doe", 2024); err == nil {
+		t.Error("renderLicense() should fail for unknown licenses")
+	}
+}
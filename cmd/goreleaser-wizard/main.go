@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +22,13 @@ var (
 	gitState       = ""
 
 	cfgFile string
+
+	// projectRoot is the project directory set by --dir; commands read and
+	// write project files and run git through it
+	projectRoot = wizard.NewRoot(".")
+	// projectWriter writes this run's files into projectRoot, backing up
+	// the files it replaces for 'restore'
+	projectWriter = wizard.NewWriter(projectRoot)
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -45,44 +55,91 @@ func Execute() {
 	defer HandlePanic("command execution", logger)
 
 	if err := rootCmd.Execute(); err != nil {
+		// Flags are parsed by now, so honour --debug and --no-color
+		logger = newLogger()
 		LogAndDisplayError(err, logger)
+		os.Exit(ExitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// Commands return errors; Execute displays them and sets the exit code
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if output := viper.GetString("output"); output != "text" && output != "json" {
+			return UserInputError("output format", fmt.Errorf("unknown output %q (valid: text, json)", output))
+		}
+
+		projectRoot = wizard.NewRoot(viper.GetString("dir"))
+		if err := projectRoot.Check(); err != nil {
+			return NewWizardError(
+				ErrProjectNotFound,
+				"Project directory not found",
+				err.Error(),
+				"Check the --dir path",
+				err,
+			)
+		}
+		projectWriter = wizard.NewWriter(projectRoot)
+		return nil
+	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return NewWizardError(ErrUserInput, "Invalid flag", err.Error(), fmt.Sprintf("Run '%s --help' for usage", cmd.CommandPath()), err)
+	})
+
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.goreleaser-wizard.yaml)")
-	rootCmd.PersistentFlags().Bool("no-color", false, "disable color output")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .goreleaser-wizard.yaml in the project directory, then $HOME)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable color output (also NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("ascii", false, "use plain ASCII instead of emoji and symbols")
+	rootCmd.PersistentFlags().StringP("dir", "C", ".", "project directory to work in")
+	rootCmd.PersistentFlags().String("templates-dir", "", "directory of template overrides (see 'templates export')")
+	rootCmd.PersistentFlags().String("template-pack", "", "template pack: a local directory or <git-url>[#ref], pinned in "+wizard.LockFile)
 	rootCmd.PersistentFlags().Bool("debug", false, "enable debug output")
+	rootCmd.PersistentFlags().String("output", "text", "error output format: text or json")
 
 	// Bind flags to viper
 	viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
+	viper.BindPFlag("ascii", rootCmd.PersistentFlags().Lookup("ascii"))
+	viper.BindPFlag("dir", rootCmd.PersistentFlags().Lookup("dir"))
+	viper.BindPFlag("templates-dir", rootCmd.PersistentFlags().Lookup("templates-dir"))
+	viper.BindPFlag("template-pack", rootCmd.PersistentFlags().Lookup("template-pack"))
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 
 	// Add commands
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(licenseCmd)
+	rootCmd.AddCommand(restoreCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	logger := log.New(os.Stderr)
-	if viper.GetBool("debug") {
-		logger.SetLevel(log.DebugLevel)
-	}
+	// Flags are parsed by now, so the logger honours --debug and --output
+	logger := newLogger()
 
 	// Set up panic recovery for config initialization
 	defer HandlePanic("config initialization", logger)
 
+	// Colors and symbols depend on flags, NO_COLOR and the config file
+	defer applyTheme()
+
+	viper.SetEnvPrefix("GORELEASER_WIZARD")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv() // read in environment variables that match
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 		// Validate the config file exists and is readable
-		if err := CheckFileExists(cfgFile, true); err != nil {
+		if err := CheckFileExists(wizard.Root{}, cfgFile, false); err != nil {
 			LogAndDisplayError(
 				NewWizardError(
 					ErrConfiguration,
@@ -112,18 +169,17 @@ func initConfig() {
 			return
 		}
 
-		// Search config in home directory with name ".goreleaser-wizard" (without extension).
+		// Search config in the project, then the home directory, with name ".goreleaser-wizard" (without extension).
+		viper.AddConfigPath(viper.GetString("dir"))
 		viper.AddConfigPath(home)
 		viper.SetConfigName(".goreleaser-wizard")
 	}
 
-	viper.SetEnvPrefix("GORELEASER_WIZARD")
-	viper.AutomaticEnv() // read in environment variables that match
-
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
 		// Only log if it's not a "file not found" error for optional config
-		if cfgFile != "" || !os.IsNotExist(err) {
+		var notFound viper.ConfigFileNotFoundError
+		if cfgFile != "" || !errors.As(err, &notFound) {
 			logger.Warn("Config file error", "error", err, "file", viper.ConfigFileUsed())
 		}
 	} else if viper.GetBool("debug") {
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// activePack caches the template pack for this run and activeLock the lock
+// file pinning it, if that needs writing; packLoaded is set once the
+// template-pack setting has been resolved, even to no pack
+var (
+	activePack *wizard.Pack
+	activeLock []byte
+	packLoaded bool
+)
+
+var templatesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the template pack and its lock file to the latest commit",
+	Long: `Fetch the git template pack named by --template-pack (or template-pack in
+the config file), resolve its ref again and pin the new commit in
+` + wizard.LockFile + `. Local directory packs are not locked.`,
+	Args: cobra.NoArgs,
+	RunE: runTemplatesUpdate,
+}
+
+func init() {
+	templatesCmd.AddCommand(templatesUpdateCmd)
+}
+
+func runTemplatesUpdate(cmd *cobra.Command, args []string) error {
+	// Set up logger
+	logger := newLogger()
+
+	// Set up panic recovery
+	defer HandlePanic("templates update command", logger)
+
+	source := viper.GetString("template-pack")
+	if source == "" {
+		return NewWizardError(ErrConfiguration, "No template pack configured", "Neither --template-pack nor template-pack in the config file is set", "Pass --template-pack <git-url>[#ref]", nil)
+	}
+	if !wizard.IsGitSource(source) {
+		fmt.Println(infoStyle.Render("ℹ " + source + " is a local directory; it is used as is and not locked"))
+		return nil
+	}
+
+	before, _ := wizard.ReadPackLock(projectRoot)
+	pack, lock, err := resolvePack(true)
+	if err != nil {
+		return err
+	}
+	if lock != nil {
+		if _, err := projectWriter.Commit(map[string][]byte{wizard.LockFile: lock}, true); err != nil {
+			return commitError(err)
+		}
+	}
+	if before.Commit == pack.Commit {
+		fmt.Println(successStyle.Render("✓ Template pack " + pack.Manifest.Name + " is up to date at " + shortCommit(pack.Commit)))
+		return nil
+	}
+	fmt.Println(successStyle.Render("✓ Updated template pack " + pack.Manifest.Name + " to " + shortCommit(pack.Commit)))
+	return nil
+}
+
+// loadPack returns the template pack named by the template-pack setting,
+// or nil when none is set. It is resolved once per run; the lock file is
+// not written here but added to the files the run commits, see packFiles.
+func loadPack() (*wizard.Pack, error) {
+	if packLoaded {
+		return activePack, nil
+	}
+	pack, lock, err := resolvePack(false)
+	if err != nil {
+		return nil, err
+	}
+	activePack, activeLock, packLoaded = pack, lock, true
+	return pack, nil
+}
+
+// packFiles adds the lock file pinning the template pack to files, when it
+// needs writing, so it is committed with them or not at all
+func packFiles(files map[string][]byte) {
+	if activeLock != nil {
+		files[wizard.LockFile] = activeLock
+	}
+}
+
+// resolvePack opens a local pack directory, or fetches a git pack written as
+// <url>[#ref]. Git packs use the commit pinned in the lock file when it
+// matches the source; otherwise, or with update, the ref is resolved again.
+// It returns the lock file contents when they differ from the file on disk.
+func resolvePack(update bool) (*wizard.Pack, []byte, error) {
+	source := viper.GetString("template-pack")
+	if source == "" {
+		return nil, nil, nil
+	}
+	if !wizard.IsGitSource(source) {
+		pack, err := wizard.OpenPack(projectRoot.Path(source))
+		if err != nil {
+			return nil, nil, packError(err)
+		}
+		return pack, nil, nil
+	}
+
+	url, ref, _ := strings.Cut(source, "#")
+	lock, err := wizard.ReadPackLock(projectRoot)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, packError(err)
+	}
+	commit := ""
+	if !update && lock.Source == url && lock.Ref == ref {
+		commit = lock.Commit
+	}
+
+	cacheDir, err := packCacheDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	pack, err := wizard.FetchPack(context.Background(), url, ref, commit, cacheDir)
+	if err != nil {
+		return nil, nil, packError(err)
+	}
+
+	pinned := wizard.PackLock{Source: url, Ref: ref, Commit: pack.Commit}
+	if pinned == lock {
+		return pack, nil, nil
+	}
+	data, err := pinned.Marshal()
+	if err != nil {
+		return nil, nil, packError(err)
+	}
+	return pack, data, nil
+}
+
+// packCacheDir is where git packs are cloned: pack-cache if set, else the
+// user cache directory
+func packCacheDir() (string, error) {
+	if dir := viper.GetString("pack-cache"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", NewWizardError(ErrPermission, "Unable to determine cache directory", err.Error(), "Set GORELEASER_WIZARD_PACK_CACHE to a writable directory", err)
+	}
+	return filepath.Join(dir, "goreleaser-wizard", "packs"), nil
+}
+
+// packError wraps a failure to load or fetch the template pack
+func packError(err error) *WizardError {
+	return NewWizardError(
+		ErrConfiguration,
+		"Cannot load template pack",
+		err.Error(),
+		"Check the template-pack setting; git packs need git and access to the repository",
+		err,
+	)
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// packSection asks the questions declared by a template pack, one at a
+// time so each when condition sees the answers before it
+func packSection(pack *wizard.Pack) wizardSection {
+	questions := pack.Manifest.Questions
+	return wizardSection{
+		Title:   "Template Pack",
+		Enabled: func(*wizard.ProjectConfig) bool { return true },
+		Ask: func(config *wizard.ProjectConfig) error {
+			if config.Custom == nil {
+				config.Custom = make(map[string]string)
+			}
+			for i := range questions {
+				question := &questions[i]
+				asked, err := question.Asked(*config)
+				if err != nil {
+					return packError(err)
+				}
+				if !asked {
+					continue
+				}
+				if err := askPackQuestion(question, config); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Summary: func(config *wizard.ProjectConfig) []reviewItem {
+			var items []reviewItem
+			for i := range questions {
+				question := &questions[i]
+				if asked, _ := question.Asked(*config); asked {
+					items = append(items, reviewItem{cmp.Or(question.Title, question.ID), config.Custom[question.ID]})
+				}
+			}
+			return items
+		},
+	}
+}
+
+// askPackQuestion asks one template pack question with the field for its type
+func askPackQuestion(question *wizard.Question, config *wizard.ProjectConfig) error {
+	title := cmp.Or(question.Title, question.ID)
+	value := config.Custom[question.ID]
+
+	var field huh.Field
+	var answer func() string
+	switch question.Type {
+	case wizard.QuestionSelect:
+		field = huh.NewSelect[string]().
+			Title(title).
+			Description(question.Description).
+			Options(huh.NewOptions(question.Options...)...).
+			Value(&value)
+		answer = func() string { return value }
+	case wizard.QuestionMultiSelect:
+		selected := wizard.SplitAnswer(value)
+		field = huh.NewMultiSelect[string]().
+			Title(title).
+			Description(question.Description).
+			Options(huh.NewOptions(question.Options...)...).
+			Value(&selected)
+		answer = func() string { return strings.Join(selected, ",") }
+	case wizard.QuestionConfirm:
+		confirmed := value == "true"
+		field = huh.NewConfirm().
+			Title(title).
+			Description(question.Description).
+			Value(&confirmed)
+		answer = func() string { return strconv.FormatBool(confirmed) }
+	default:
+		field = huh.NewInput().
+			Title(title).
+			Description(question.Description).
+			Value(&value).
+			Validate(question.Check)
+		answer = func() string { return value }
+	}
+
+	if err := newForm(huh.NewGroup(field)).Run(); err != nil {
+		return err
+	}
+	config.Custom[question.ID] = answer()
+	return nil
+}
+
+// checkPackAnswers fills pack defaults and validates the pack answers
+func checkPackAnswers(pack *wizard.Pack, config *wizard.ProjectConfig) error {
+	if pack == nil {
+		return nil
+	}
+	pack.ApplyDefaults(config)
+	if err := pack.CheckAnswers(*config); err != nil {
+		if errors.Is(err, wizard.ErrPack) {
+			return packError(err)
+		}
+		return UserInputError("template pack answer", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+func TestWriteProjectFilesPinsPack(t *testing.T) {
+	root := newMemRoot(t)
+	if err := root.WriteFile(wizard.ConfigFile, []byte("version: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	savedRoot, savedWriter := projectRoot, projectWriter
+	projectRoot, projectWriter = root, wizard.NewWriter(root)
+	activeLock, packLoaded = []byte("source: /srv/pack.git\n"), true
+	t.Cleanup(func() {
+		projectRoot, projectWriter = savedRoot, savedWriter
+		activeLock, packLoaded = nil, false
+	})
+	config := &wizard.ProjectConfig{ProjectName: "tool", BinaryName: "tool", MainPath: "."}
+
+	// The lock is only written with the files it was resolved for
+	if err := writeProjectFiles(config, false); ExitCode(err) != exitCodes[ErrConfigExists] {
+		t.Fatalf("writeProjectFiles() over an existing config = %v, want ErrConfigExists", err)
+	}
+	if root.Exists(wizard.LockFile) || root.Exists(wizard.BackupsDir) {
+		t.Error("writeProjectFiles() pinned the pack although nothing was written")
+	}
+
+	if err := writeProjectFiles(config, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := root.ReadFile(wizard.LockFile); string(data) != string(activeLock) {
+		t.Errorf("%s = %q, want the pinned pack", wizard.LockFile, data)
+	}
+}
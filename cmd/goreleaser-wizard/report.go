@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+// Output formats accepted by validate --format
+var validateFormats = []string{"text", "json", "sarif"}
+
+// validateFormat checks a --format value
+func validateFormat(format string) error {
+	if !slices.Contains(validateFormats, format) {
+		return fmt.Errorf("unknown format %q (valid: %s)", format, strings.Join(validateFormats, ", "))
+	}
+	return nil
+}
+
+// writeReport renders the report in the requested format
+func writeReport(w io.Writer, report *wizard.Report, format string, verbose, fix bool) error {
+	switch format {
+	case "json":
+		return writeJSONReport(w, report)
+	case "sarif":
+		return writeSARIFReport(w, report)
+	default:
+		writeTextReport(w, report, verbose, fix)
+		return nil
+	}
+}
+
+// writeTextReport prints the styled check list and summary
+func writeTextReport(w io.Writer, report *wizard.Report, verbose, fix bool) {
+	for _, check := range report.Checks {
+		// Verbose output names each rule and how long it took
+		suffix := ""
+		if verbose {
+			suffix = fmt.Sprintf(" [%s, %s]", check.Rule, check.Duration.Round(time.Microsecond))
+		}
+		if check.Passed {
+			fmt.Fprintln(w, successStyle.Render("✓ "+check.Message)+suffix)
+			if verbose && check.Details != "" {
+				fmt.Fprintln(w, infoStyle.Render("  → "+check.Details))
+			}
+			continue
+		}
+		for _, finding := range check.Findings {
+			switch finding.Severity {
+			case wizard.SeverityError:
+				fmt.Fprintln(w, errorStyle.Render("✗ "+finding.Message)+suffix)
+			case wizard.SeverityWarning:
+				fmt.Fprintln(w, errorStyle.Render("⚠ "+finding.Message)+suffix)
+			default:
+				fmt.Fprintln(w, infoStyle.Render("ℹ "+finding.Message)+suffix)
+			}
+			if verbose && finding.Details != "" {
+				fmt.Fprintln(w, infoStyle.Render("  → "+finding.Details))
+			}
+			if fix {
+				for _, step := range finding.Fix {
+					fmt.Fprintln(w, infoStyle.Render("  → "+step))
+				}
+			}
+		}
+	}
+
+	if verbose && len(report.Suppressed) > 0 {
+		fmt.Fprintln(w, infoStyle.Render(fmt.Sprintf("ℹ %d finding(s) suppressed", len(report.Suppressed))))
+		for _, finding := range report.Suppressed {
+			fmt.Fprintln(w, infoStyle.Render(fmt.Sprintf("  → %s: %s", finding.RuleID, finding.Message)))
+		}
+	}
+
+	var issues, warnings []string
+	for _, finding := range report.Findings() {
+		if finding.Severity == wizard.SeverityError {
+			issues = append(issues, finding.Message)
+		} else {
+			warnings = append(warnings, finding.Message)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, titleStyle.Render("📊 Validation Summary"))
+	fmt.Fprintf(w, "Checks passed: %d/%d\n", report.Passed(), len(report.Checks))
+
+	if len(issues) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, errorStyle.Render("❌ Critical Issues:"))
+		for _, issue := range issues {
+			fmt.Fprintln(w, plain("  • "+issue))
+		}
+	}
+
+	if len(warnings) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, infoStyle.Render("⚠️  Warnings:"))
+		for _, warning := range warnings {
+			fmt.Fprintln(w, plain("  • "+warning))
+		}
+	}
+
+	fmt.Fprintln(w)
+	if len(issues) == 0 {
+		fmt.Fprintln(w, successStyle.Render("✨ Configuration looks good!"))
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Next steps:")
+		fmt.Fprintln(w, "  1. Test build: goreleaser build --snapshot --clean")
+		fmt.Fprintln(w, "  2. Create tag: git tag -a v0.1.0 -m 'First release'")
+		fmt.Fprintln(w, "  3. Push tag: git push origin v0.1.0")
+	} else {
+		fmt.Fprintln(w, errorStyle.Render("⚠️  Please fix the issues above before releasing"))
+		if !fix {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "Run with --fix to see suggested fixes")
+		}
+	}
+}
+
+// jsonReport is the validate --format json document
+type jsonReport struct {
+	Passed     int              `json:"passed"`
+	Total      int              `json:"total"`
+	Errors     int              `json:"errors"`
+	Warnings   int              `json:"warnings"`
+	Findings   []wizard.Finding `json:"findings"`
+	Suppressed []wizard.Finding `json:"suppressed,omitempty"`
+}
+
+func writeJSONReport(w io.Writer, report *wizard.Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{
+		Passed:     report.Passed(),
+		Total:      len(report.Checks),
+		Errors:     report.Count(wizard.SeverityError),
+		Warnings:   report.Count(wizard.SeverityWarning),
+		Findings:   report.Findings(),
+		Suppressed: report.Suppressed,
+	})
+}
+
+// SARIF 2.1.0 subset understood by code scanning UIs
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	ShortDescription sarifMessage        `json:"shortDescription"`
+	Properties       sarifRuleProperties `json:"properties"`
+}
+
+type sarifRuleProperties struct {
+	Category string `json:"category"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        wizard.Severity    `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   *sarifProperties   `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifProperties struct {
+	SuggestedFix []string `json:"suggestedFix,omitempty"`
+}
+
+func writeSARIFReport(w io.Writer, report *wizard.Report) error {
+	rules := []sarifRule{}
+	for _, rule := range report.Rules {
+		rules = append(rules, sarifRule{
+			ID:               rule.ID,
+			ShortDescription: sarifMessage{Text: rule.Description},
+			Properties:       sarifRuleProperties{Category: rule.Category},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, finding := range report.Findings() {
+		results = append(results, sarifResultFor(finding))
+	}
+	// Suppressed findings stay visible to code scanning as dismissed results
+	for _, finding := range report.Suppressed {
+		result := sarifResultFor(finding)
+		kind := "external"
+		if finding.SuppressedBy == wizard.SuppressedInline {
+			kind = "inSource"
+		}
+		result.Suppressions = []sarifSuppression{{Kind: kind}}
+		results = append(results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "goreleaser-wizard",
+				Version:        version,
+				InformationURI: "https://github.com/LarsArtmann/template-GoReleaser",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
+
+// sarifResultFor converts a finding to a SARIF result
+func sarifResultFor(finding wizard.Finding) sarifResult {
+	result := sarifResult{
+		RuleID:  finding.RuleID,
+		Level:   finding.Severity,
+		Message: sarifMessage{Text: finding.Message},
+	}
+	if finding.Details != "" {
+		result.Message.Text += ": " + finding.Details
+	}
+	if finding.File != "" {
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: finding.File},
+		}}
+		if finding.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Line}
+		}
+		result.Locations = []sarifLocation{location}
+	}
+	if len(finding.Fix) > 0 {
+		result.Properties = &sarifProperties{SuggestedFix: finding.Fix}
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+func sampleReport() *wizard.Report {
+	report := &wizard.Report{Rules: wizard.Rules()}
+	report.Checks = append(report.Checks, wizard.Pass("go.mod exists", ""))
+	report.Checks = append(report.Checks, wizard.Fail(wizard.Finding{
+		RuleID:   "pro-only-keys",
+		Severity: wizard.SeverityError,
+		Message:  "Pro-only key 'nightly' used without GoReleaser Pro",
+		File:     ".goreleaser.yaml",
+		Line:     12,
+		Fix:      []string{"Set 'distribution: goreleaser-pro' in your release workflow"},
+	}))
+	report.Checks = append(report.Checks, wizard.Fail(wizard.Finding{
+		RuleID:   "release-workflow",
+		Severity: wizard.SeverityNote,
+		Message:  "No GitHub Actions workflow for releases",
+	}))
+	return report
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeReport(&buf, sampleReport(), "json", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var got jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Passed != 1 || got.Total != 3 || got.Errors != 1 || got.Warnings != 0 {
+		t.Errorf("Counts = %+v", got)
+	}
+	if len(got.Findings) != 2 || got.Findings[0].Line != 12 || got.Findings[0].RuleID != "pro-only-keys" {
+		t.Errorf("Findings = %+v", got.Findings)
+	}
+}
+
+func TestWriteSARIFReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeReport(&buf, sampleReport(), "sarif", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var got sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid SARIF: %v\n%s", err, buf.String())
+	}
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF envelope: %+v", got)
+	}
+
+	run := got.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(wizard.Rules()) {
+		t.Errorf("Rules = %d, want %d", len(run.Tool.Driver.Rules), len(wizard.Rules()))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("Results = %d, want 2", len(run.Results))
+	}
+
+	located := run.Results[0]
+	if located.Level != wizard.SeverityError || len(located.Locations) != 1 {
+		t.Fatalf("Unexpected result: %+v", located)
+	}
+	physical := located.Locations[0].PhysicalLocation
+	if physical.ArtifactLocation.URI != ".goreleaser.yaml" || physical.Region == nil || physical.Region.StartLine != 12 {
+		t.Errorf("Location = %+v", physical)
+	}
+	if len(run.Results[1].Locations) != 0 {
+		t.Errorf("Finding without file should have no location: %+v", run.Results[1])
+	}
+}
+
+func TestWriteTextReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeReport(&buf, sampleReport(), "text", false, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"go.mod exists", "nightly", "distribution: goreleaser-pro", "Checks passed: 1/3"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Text report missing %q", want)
+		}
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range validateFormats {
+		if err := validateFormat(format); err != nil {
+			t.Errorf("validateFormat(%q) = %v", format, err)
+		}
+	}
+	if err := validateFormat("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
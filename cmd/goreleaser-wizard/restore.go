@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Roll back the files written by the last run",
+	Long: `Every run that writes files backs up the files it replaces to
+` + wizard.BackupsDir + `/<timestamp>/. restore puts those files back
+and removes the files the run created, then deletes the backup, so running
+it again rolls back the run before.`,
+	Args: cobra.NoArgs,
+	RunE: runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().Bool("list", false, "list the backups instead of restoring")
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	logger := newLogger()
+
+	// Set up panic recovery
+	defer HandlePanic("restore command", logger)
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		return listBackups()
+	}
+
+	backup, err := projectRoot.Restore()
+	if errors.Is(err, wizard.ErrNoBackup) {
+		return NewWizardError(
+			ErrProjectNotFound,
+			"Nothing to restore",
+			fmt.Sprintf("No backups in %s", projectRoot.Path(wizard.BackupsDir)),
+			"Backups are made when generate, init, license or templates export replace or create files",
+			err,
+		)
+	}
+	if err != nil {
+		return WrapFileError("restore backup", projectRoot.Path(wizard.BackupsDir), err)
+	}
+
+	for _, name := range backup.Replaced {
+		fmt.Println(successStyle.Render("✓ Restored " + projectRoot.Path(name)))
+	}
+	for _, name := range backup.Created {
+		fmt.Println(successStyle.Render("✓ Removed " + projectRoot.Path(name)))
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("\n✨ Rolled back the run from %s", backup.Time.Local().Format("2006-01-02 15:04:05"))))
+	return nil
+}
+
+// listBackups prints the project's backups, newest first
+func listBackups() error {
+	backups, err := projectRoot.Backups()
+	if err != nil {
+		return WrapFileError("list backups", projectRoot.Path(wizard.BackupsDir), err)
+	}
+	if len(backups) == 0 {
+		fmt.Println(infoStyle.Render("No backups in " + projectRoot.Path(wizard.BackupsDir)))
+		return nil
+	}
+	for _, backup := range backups {
+		fmt.Printf("%s  %s  %d replaced, %d created\n", backup.ID, backup.Time.Local().Format("2006-01-02 15:04:05"), len(backup.Replaced), len(backup.Created))
+	}
+	return nil
+}
+
+// reportBackup tells the user how to undo a run that replaced files
+func reportBackup(w *wizard.Writer) {
+	if backup := w.Backup(); backup != nil && len(backup.Replaced) > 0 {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("• Replaced files were backed up to %s; run 'goreleaser-wizard restore' to undo", w.Root().Path(backup.Dir()))))
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/charmbracelet/huh"
+)
+
+// Review menu actions that are not section edits
+const (
+	reviewWrite           = "write"
+	reviewPreviewConfig   = "preview-config"
+	reviewPreviewWorkflow = "preview-workflow"
+	reviewCancel          = "cancel"
+)
+
+// reviewItem is one labelled answer shown on the review screen
+type reviewItem struct {
+	Label string
+	Value string
+}
+
+// wizardSection is one group of init questions that can be revisited from the review screen
+type wizardSection struct {
+	Title   string
+	Enabled func(config *wizard.ProjectConfig) bool
+	Ask     func(config *wizard.ProjectConfig) error
+	Summary func(config *wizard.ProjectConfig) []reviewItem
+}
+
+// wizardSections returns the init sections in the order they are asked.
+// askPro is false when --pro already answered the GoReleaser Pro question.
+func wizardSections(askPro bool) []wizardSection {
+	always := func(*wizard.ProjectConfig) bool { return true }
+	notMinimal := func(config *wizard.ProjectConfig) bool { return !config.Minimal }
+
+	return []wizardSection{
+		{
+			Title:   "Basic Information",
+			Enabled: always,
+			Ask:     askBasicInfo,
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				return []reviewItem{
+					{"Project Name", config.ProjectName},
+					{"Description", config.ProjectDescription},
+					{"Project Type", config.ProjectType},
+					{"Binary Name", config.BinaryName},
+					{"Main Package", config.MainPath},
+					{"License", config.License},
+				}
+			},
+		},
+		{
+			Title:   "Build Options",
+			Enabled: always,
+			Ask:     askBuildOptions,
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				return []reviewItem{
+					{"Platforms", formatList(config.Platforms)},
+					{"Architectures", formatList(config.Architectures)},
+					{"CGO", formatBool(config.CGOEnabled)},
+					{"Version Info", formatBool(config.LDFlags)},
+					{"Build Tags", formatList(config.BuildTags)},
+				}
+			},
+		},
+		{
+			Title:   "Architecture Variants",
+			Enabled: notMinimal,
+			Ask:     askBuildVariants,
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				var overrides []string
+				for _, override := range config.BuildOverrides {
+					overrides = append(overrides, override.Spec())
+				}
+				return []reviewItem{
+					{"GOARM", formatList(config.GoARM)},
+					{"GOAMD64", formatList(config.GoAMD64)},
+					{"GOMIPS", formatList(config.GoMIPS)},
+					{"Overrides", formatList(overrides)},
+				}
+			},
+		},
+		{
+			Title:   "Release Options",
+			Enabled: always,
+			Ask:     askReleaseOptions,
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				items := []reviewItem{{"Git Provider", config.GitProvider}}
+				if config.Minimal {
+					return items
+				}
+				return append(items,
+					reviewItem{"Docker", formatBool(config.DockerEnabled)},
+					reviewItem{"Docker Registry", config.DockerRegistry},
+					reviewItem{"Signing", formatBool(config.Signing)},
+					reviewItem{"SBOM", formatBool(config.SBOM)},
+					reviewItem{"Homebrew", formatBool(config.Homebrew)},
+					reviewItem{"Snap", formatBool(config.Snap)},
+				)
+			},
+		},
+		{
+			Title:   "Advanced Options",
+			Enabled: notMinimal,
+			Ask: func(config *wizard.ProjectConfig) error {
+				return askAdvancedOptions(config, askPro)
+			},
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				return []reviewItem{
+					{"GitHub Actions", formatBool(config.GenerateActions)},
+					{"Triggers", formatList(config.ActionsOn)},
+					{"Compression", config.Compression},
+					{"UPX", formatBool(config.UPX)},
+					{"Universal Binaries", formatBool(config.UniversalBinaries)},
+					{"GoReleaser Pro", formatBool(config.ProVersion)},
+					{"Run Tests", formatBool(!config.SkipTests)},
+					{"Before Hooks", formatList(config.Hooks)},
+					{"Post-Build Hooks", formatList(config.PostBuildHooks)},
+				}
+			},
+		},
+		{
+			Title:   "GoReleaser Pro Options",
+			Enabled: func(config *wizard.ProjectConfig) bool { return config.ProVersion },
+			Ask:     askProOptions,
+			Summary: func(config *wizard.ProjectConfig) []reviewItem {
+				var templated []string
+				for _, file := range config.TemplatedFiles {
+					templated = append(templated, file.Src+" → "+file.Dst)
+				}
+				return []reviewItem{
+					{"Linux Packages", formatList(config.NFPMFormats)},
+					{"Changelog Groups", formatBool(config.ChangelogGroups)},
+					{"Source Archive", formatBool(config.SourceArchive)},
+					{"Scoop", formatBool(config.Scoop)},
+					{"Nightly", formatBool(config.Nightly)},
+					{"Split/Merge", formatBool(config.Partial)},
+					{"Monorepo Prefix", config.MonorepoPrefix},
+					{"Before Publish", formatList(config.BeforePublish)},
+					{"Templated Files", formatList(templated)},
+					{"Includes", formatList(config.Includes)},
+				}
+			},
+		},
+	}
+}
+
+// reviewConfig shows every answer grouped by section and lets the user edit
+// a section, preview the generated files or confirm. It returns false when
+// the user cancels without writing anything.
+func reviewConfig(config *wizard.ProjectConfig, sections []wizardSection) (bool, error) {
+	asked := make(map[string]bool)
+	for _, section := range sections {
+		if section.Enabled(config) {
+			asked[section.Title] = true
+		}
+	}
+
+	for {
+		fmt.Println()
+		fmt.Println(titleStyle.Render("📋 Review Configuration"))
+		fmt.Println(renderReviewSummary(config, sections))
+
+		options := []huh.Option[string]{
+			huh.NewOption(plain("✓ Write files"), reviewWrite),
+			huh.NewOption("Preview .goreleaser.yaml", reviewPreviewConfig),
+		}
+		if config.GenerateActions {
+			options = append(options, huh.NewOption("Preview release workflow", reviewPreviewWorkflow))
+		}
+		for _, section := range sections {
+			if section.Enabled(config) {
+				options = append(options, huh.NewOption("Edit "+section.Title, section.Title))
+			}
+		}
+		options = append(options, huh.NewOption(plain("✗ Cancel (write nothing)"), reviewCancel))
+
+		action := reviewWrite
+		menu := newForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("What next?").
+					Options(options...).
+					Value(&action),
+			),
+		)
+		if err := menu.Run(); err != nil {
+			return false, formError("review", err)
+		}
+
+		switch action {
+		case reviewWrite:
+			return true, nil
+		case reviewCancel:
+			return false, nil
+		case reviewPreviewConfig:
+			showPreview(wizard.ConfigFile, config)
+		case reviewPreviewWorkflow:
+			showPreview(wizard.WorkflowFile, config)
+		default:
+			if err := editSection(config, sections, action, asked); err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// editSection re-asks the named section, then asks any sections the edit
+// newly enabled (e.g. Pro options after turning Pro on)
+func editSection(config *wizard.ProjectConfig, sections []wizardSection, title string, asked map[string]bool) error {
+	for _, section := range sections {
+		if section.Title == title {
+			if err := section.Ask(config); err != nil {
+				return formError(strings.ToLower(section.Title), err)
+			}
+		}
+	}
+
+	for _, section := range sections {
+		if section.Enabled(config) && !asked[section.Title] {
+			if err := section.Ask(config); err != nil {
+				return formError(strings.ToLower(section.Title), err)
+			}
+			asked[section.Title] = true
+		}
+	}
+	return nil
+}
+
+// renderReviewSummary formats the answers of every enabled section
+func renderReviewSummary(config *wizard.ProjectConfig, sections []wizardSection) string {
+	var b strings.Builder
+	for _, section := range sections {
+		if !section.Enabled(config) {
+			continue
+		}
+		b.WriteString("\n" + sectionStyle.Render(section.Title) + "\n")
+		for _, item := range section.Summary(config) {
+			value := item.Value
+			if value == "" {
+				value = "—"
+			}
+			b.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(item.Label+":"), plain(value)))
+		}
+	}
+	return b.String()
+}
+
+// showPreview renders one output file in memory and prints it
+func showPreview(name string, config *wizard.ProjectConfig) {
+	// Render works on a copy, so defaults applied while rendering don't leak into answers
+	files, err := renderProjectFiles(config)
+	if err != nil {
+		HandleError(err, nil)
+		return
+	}
+	content := files[name]
+	fmt.Println()
+	fmt.Println(sectionStyle.Render("Preview: " + name))
+	fmt.Println(previewStyle.Render(strings.TrimRight(string(content), "\n")))
+}
+
+// formatBool renders a yes/no answer
+func formatBool(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// formatList renders a list answer on one line
+func formatList(values []string) string {
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+)
+
+func TestRenderReviewSummary(t *testing.T) {
+	config := &wizard.ProjectConfig{
+		ProjectName:   "review-app",
+		BinaryName:    "review-app",
+		Platforms:     []string{"linux", "darwin"},
+		Architectures: []string{"amd64"},
+		GitProvider:   "GitHub",
+		Minimal:       true,
+	}
+	sections := wizardSections(true)
+
+	summary := renderReviewSummary(config, sections)
+	for _, want := range []string{"Basic Information", "review-app", "linux, darwin", "Release Options", "GitHub"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Architecture Variants", "Advanced Options", "GoReleaser Pro Options", "Docker Registry"} {
+		if strings.Contains(summary, unwanted) {
+			t.Errorf("Minimal summary should not contain %q", unwanted)
+		}
+	}
+
+	config.Minimal = false
+	config.ProVersion = true
+	summary = renderReviewSummary(config, sections)
+	for _, want := range []string{"Advanced Options", "GoReleaser Pro Options", "Docker Registry"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary missing %q", want)
+		}
+	}
+}
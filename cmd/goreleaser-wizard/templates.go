@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
+	"github.com/spf13/cobra"
+)
+
+// defaultTemplatesDir is where templates export writes by default
+const defaultTemplatesDir = ".goreleaser-wizard/templates"
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Work with the templates used to generate release files",
+	Long: `Release files are rendered from Go templates embedded in the binary.
+Files in --templates-dir (or templates-dir in the config file) replace the
+built-in file at the same path, so you can override a single partial.`,
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "Write the built-in templates to a directory to customize",
+	Long: `Write the built-in templates to dir (default ` + defaultTemplatesDir + `)
+as a starting point for overrides. Keep the files you change and delete
+the rest, so future built-in improvements still apply to them.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplatesExport,
+}
+
+func init() {
+	templatesExportCmd.Flags().Bool("force", false, "overwrite existing files")
+	templatesCmd.AddCommand(templatesExportCmd)
+}
+
+func runTemplatesExport(cmd *cobra.Command, args []string) error {
+	// Set up logger
+	logger := newLogger()
+
+	// Set up panic recovery
+	defer HandlePanic("templates export command", logger)
+
+	dir := defaultTemplatesDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	force, _ := cmd.Flags().GetBool("force")
+
+	files, skipped := map[string][]byte{}, 0
+	err := fs.WalkDir(wizard.Templates, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		target := path.Join(dir, name)
+		if !force && projectRoot.Exists(target) {
+			fmt.Println(infoStyle.Render("• Skipped " + projectRoot.Path(target) + " (exists, use --force to overwrite)"))
+			skipped++
+			return nil
+		}
+		content, err := fs.ReadFile(wizard.Templates, name)
+		if err != nil {
+			return TemplateError(name, err)
+		}
+		files[target] = content
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Written together, so a failure leaves no partial set of overrides
+	statuses, err := projectWriter.Commit(files, force)
+	if err != nil {
+		return commitError(err)
+	}
+	reportFiles(slices.Sorted(maps.Keys(files)), statuses, "Exported")
+	exported := 0
+	for _, status := range statuses {
+		if status == wizard.FileUnchanged {
+			skipped++
+		} else {
+			exported++
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(successStyle.Render(fmt.Sprintf("✨ Exported %d template(s), skipped %d", exported, skipped)))
+	reportBackup(projectWriter)
+	fmt.Println(infoStyle.Render(fmt.Sprintf("Edit the files you need, delete the rest, then use --templates-dir %s or 'templates-dir: %s' in .goreleaser-wizard.yaml", dir, dir)))
+	return nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+)
+
+var (
+	// noColorMode drops colors and text attributes (--no-color, NO_COLOR)
+	noColorMode bool
+
+	// asciiMode replaces emoji and symbols with ASCII on stdout (--ascii,
+	// or stdout is not a terminal)
+	asciiMode bool
+
+	// stderrASCII is asciiMode for stderr, which is themed on its own: it is
+	// often still a terminal when stdout is piped, or the other way round
+	stderrASCII bool
+
+	// stderrRenderer detects the colors stderr supports
+	stderrRenderer = lipgloss.NewRenderer(os.Stderr)
+)
+
+// themeStyle is a lipgloss style whose output honours the ASCII theme.
+// Colors are dropped by lipgloss itself once the color profile is ASCII,
+// which is also what it detects for non-terminal output.
+type themeStyle struct {
+	lipgloss.Style
+}
+
+// Render renders strs with the style for stdout, in ASCII when asciiMode is set
+func (s themeStyle) Render(strs ...string) string {
+	return s.Style.Render(plain(strings.Join(strs, " ")))
+}
+
+// Stderr renders strs with the style for stderr, in ASCII when stderrASCII
+// is set and in the colors stderr supports
+func (s themeStyle) Stderr(strs ...string) string {
+	text := strings.Join(strs, " ")
+	if stderrASCII {
+		text = toASCII(text)
+	}
+	return s.Style.Renderer(stderrRenderer).Render(text)
+}
+
+var (
+	// Style definitions
+	titleStyle = themeStyle{lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("99")).
+			MarginBottom(1)}
+
+	successStyle = themeStyle{lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Bold(true)}
+
+	errorStyle = themeStyle{lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Bold(true)}
+
+	infoStyle = themeStyle{lipgloss.NewStyle().
+			Foreground(lipgloss.Color("86"))}
+
+	suggestStyle = themeStyle{lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220")).
+			Bold(true)}
+
+	sectionStyle = themeStyle{lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("99"))}
+
+	labelStyle = themeStyle{lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))}
+
+	previewStyle = themeStyle{lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("62")).
+			Padding(0, 1)}
+)
+
+// asciiSymbols maps status symbols to ASCII; other emoji are dropped
+var asciiSymbols = strings.NewReplacer(
+	"✓", "[ok]",
+	"✗", "[x]",
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"ℹ", "[i]",
+	"→", "->",
+	"•", "*",
+	"—", "-",
+	"…", "...",
+)
+
+// applyTheme reads --no-color, NO_COLOR and --ascii once config is loaded.
+// stdout and stderr each fall back to ASCII when they are not a terminal,
+// so piped output and CI logs get no emoji.
+func applyTheme() {
+	noColorMode = viper.GetBool("no-color") || os.Getenv("NO_COLOR") != ""
+	ascii := viper.GetBool("ascii")
+	asciiMode = ascii || !isTerminal(os.Stdout)
+	stderrASCII = ascii || !isTerminal(os.Stderr)
+	stderrRenderer = lipgloss.NewRenderer(os.Stderr)
+
+	if noColorMode {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		stderrRenderer.SetColorProfile(termenv.Ascii)
+	}
+	if asciiMode {
+		previewStyle.Style = previewStyle.Style.Border(asciiBorder)
+	}
+}
+
+// asciiBorder draws boxes with plain ASCII characters
+var asciiBorder = lipgloss.Border{
+	Top:         "-",
+	Bottom:      "-",
+	Left:        "|",
+	Right:       "|",
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+}
+
+// plain returns s unchanged, or in ASCII when asciiMode is set
+func plain(s string) string {
+	if !asciiMode {
+		return s
+	}
+	return toASCII(s)
+}
+
+// toASCII replaces symbols in s and removes emoji
+func toASCII(s string) string {
+	s = asciiSymbols.Replace(s)
+
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.So, r), r == '️', r == '‍':
+			// Drop the emoji and the space that separated it from the text
+			skipSpace = true
+			continue
+		case skipSpace && r == ' ':
+			skipSpace = false
+			continue
+		}
+		skipSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// newLogger returns a stderr logger honouring --debug and --no-color. With
+// --output json it logs JSON lines, so stderr stays machine-readable.
+func newLogger() *log.Logger {
+	logger := log.New(os.Stderr)
+	if viper.GetBool("debug") {
+		logger.SetLevel(log.DebugLevel)
+	}
+	if noColorMode {
+		logger.SetColorProfile(termenv.Ascii)
+	}
+	if viper.GetString("output") == "json" {
+		logger.SetFormatter(log.JSONFormatter)
+	}
+	return logger
+}
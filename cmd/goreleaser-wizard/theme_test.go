@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+func TestPlain(t *testing.T) {
+	defer func(saved bool) { asciiMode = saved }(asciiMode)
+
+	asciiMode = false
+	if got := plain("✓ Done"); got != "✓ Done" {
+		t.Errorf("plain() without ASCII mode = %q", got)
+	}
+
+	asciiMode = true
+	tests := []struct {
+		in, want string
+	}{
+		{"✓ go.mod exists", "[ok] go.mod exists"},
+		{"✗ Config missing", "[x] Config missing"},
+		{"⚠️  Warnings:", "[!]  Warnings:"},
+		{"  → run goreleaser check", "  -> run goreleaser check"},
+		{"  • MIT", "  * MIT"},
+		{"🚀 GoReleaser Wizard", "GoReleaser Wizard"},
+		{"❌ Error: bad input", "Error: bad input"},
+		{"💡 Suggestion: try again", "Suggestion: try again"},
+		{"\n✨ Configuration looks good!", "\nConfiguration looks good!"},
+		{"src → dst", "src -> dst"},
+		{"—", "-"},
+	}
+	for _, tt := range tests {
+		got := plain(tt.in)
+		if got != tt.want {
+			t.Errorf("plain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		for _, r := range got {
+			if r > 127 {
+				t.Errorf("plain(%q) = %q contains non-ASCII %q", tt.in, got, r)
+				break
+			}
+		}
+	}
+}
+
+func TestNewLoggerJSON(t *testing.T) {
+	viper.Set("output", "json")
+	defer viper.Set("output", "text")
+
+	var buf bytes.Buffer
+	logger := newLogger()
+	logger.SetOutput(&buf)
+	logger.Info("Pinned template pack", "pack", "acme")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil || line["msg"] != "Pinned template pack" || line["pack"] != "acme" {
+		t.Errorf("newLogger() with --output json wrote %q, want a JSON line", buf.String())
+	}
+}
+
+// saveTheme restores the theme and the standard streams after a test
+func saveTheme(t *testing.T) {
+	t.Helper()
+	ascii, stderr, noColor := asciiMode, stderrASCII, noColorMode
+	renderer, preview := stderrRenderer, previewStyle
+	stdout, stderrFile := os.Stdout, os.Stderr
+	t.Cleanup(func() {
+		asciiMode, stderrASCII, noColorMode = ascii, stderr, noColor
+		stderrRenderer, previewStyle = renderer, preview
+		os.Stdout, os.Stderr = stdout, stderrFile
+	})
+}
+
+func TestStderrStyle(t *testing.T) {
+	saveTheme(t)
+	asciiMode, stderrASCII = false, true
+	stderrRenderer = lipgloss.NewRenderer(io.Discard)
+
+	if got := errorStyle.Stderr("❌ Error: bad input"); got != "Error: bad input" {
+		t.Errorf("Stderr() = %q, want plain ASCII", got)
+	}
+	if got := errorStyle.Render("❌ Error: bad input"); !strings.Contains(got, "❌") {
+		t.Errorf("Render() = %q, want stdout themed on its own", got)
+	}
+}
+
+func TestApplyThemeWithoutTerminal(t *testing.T) {
+	saveTheme(t)
+	t.Setenv("NO_COLOR", "")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	os.Stdout, os.Stderr = w, w
+
+	applyTheme()
+	if !asciiMode || !stderrASCII {
+		t.Fatalf("applyTheme() on pipes: asciiMode=%v stderrASCII=%v, want both", asciiMode, stderrASCII)
+	}
+
+	HandleError(NewWizardError(ErrUserInput, "Bad input", "", "Try again", errors.New("bad")), nil)
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "Error: Bad input") || !strings.Contains(string(out), "Suggestion: Try again") {
+		t.Errorf("HandleError() wrote %q", out)
+	}
+	for _, r := range string(out) {
+		if r > 127 {
+			t.Errorf("HandleError() on a pipe wrote non-ASCII %q in %q", r, out)
+			break
+		}
+	}
+}
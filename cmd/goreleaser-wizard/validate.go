@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
+	"os/signal"
+	"syscall"
 
-	"github.com/charmbracelet/log"
+	"github.com/LarsArtmann/template-GoReleaser/pkg/wizard"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -23,249 +23,87 @@ This command will:
 - Verify project structure matches configuration
 - Check for missing dependencies
 - Suggest improvements`,
-	Run: runValidate,
+	RunE: runValidate,
 }
 
 func init() {
 	validateCmd.Flags().Bool("verbose", false, "show detailed validation output")
 	validateCmd.Flags().Bool("fix", false, "attempt to fix common issues")
+	validateCmd.Flags().String("format", "text", "output format: text, json or sarif")
+	validateCmd.Flags().StringSlice("enable", nil, "run only these rule IDs or categories")
+	validateCmd.Flags().StringSlice("disable", nil, "skip these rule IDs or categories")
+	validateCmd.Flags().Bool("list-rules", false, "list available rules and exit")
+	validateCmd.Flags().Duration("timeout", wizard.DefaultTimeout, "time limit for each check")
 }
 
-func runValidate(cmd *cobra.Command, args []string) {
+func runValidate(cmd *cobra.Command, args []string) error {
 	// Set up logger
-	logger := log.New(os.Stderr)
-	if viper.GetBool("debug") {
-		logger.SetLevel(log.DebugLevel)
-	}
+	logger := newLogger()
 
 	// Set up panic recovery
 	defer HandlePanic("validate command", logger)
 
-	verbose, _ := cmd.Flags().GetBool("verbose")
-	fix, _ := cmd.Flags().GetBool("fix")
-
-	fmt.Println(titleStyle.Render("🔍 Validating GoReleaser Configuration"))
-	fmt.Println()
-
-	issues := []string{}
-	warnings := []string{}
-	passed := 0
-	total := 0
-
-	// Check 1: .goreleaser.yaml exists
-	total++
-	if err := CheckFileExists(".goreleaser.yaml", false); err != nil {
-		issues = append(issues, ".goreleaser.yaml not found")
-		fmt.Println(errorStyle.Render("✗ .goreleaser.yaml not found"))
-		if fix {
-			fmt.Println(infoStyle.Render("  → Run 'goreleaser-wizard init' to create one"))
+	if list, _ := cmd.Flags().GetBool("list-rules"); list {
+		fmt.Println(titleStyle.Render("📏 Validation Rules"))
+		for _, rule := range wizard.Rules() {
+			fmt.Printf("  %-22s %-8s %-8s %s\n", rule.ID, rule.Category, rule.Severity, rule.Description)
 		}
-		logger.Debug("GoReleaser config check", "error", err)
-	} else {
-		passed++
-		fmt.Println(successStyle.Render("✓ .goreleaser.yaml exists"))
+		return nil
 	}
 
-	// Check 2: go.mod exists
-	total++
-	if err := CheckFileExists("go.mod", false); err != nil {
-		issues = append(issues, "go.mod not found")
-		fmt.Println(errorStyle.Render("✗ go.mod not found"))
-		if fix {
-			fmt.Println(infoStyle.Render("  → Run 'go mod init <module-name>' to create one"))
-		}
-		logger.Debug("Go module check", "error", err)
-	} else {
-		passed++
-		fmt.Println(successStyle.Render("✓ go.mod exists"))
-	}
-
-	// Check 3: Git repository
-	total++
-	if err := CheckFileExists(".git", false); err != nil {
-		warnings = append(warnings, "Not a git repository")
-		fmt.Println(errorStyle.Render("⚠ Not a git repository"))
-		fmt.Println(infoStyle.Render("  → GoReleaser requires a git repository to work"))
-		if fix {
-			fmt.Println(infoStyle.Render("  → Run 'git init' to initialize repository"))
-		}
-		logger.Debug("Git repository check", "error", err)
-	} else {
-		// Check for uncommitted changes with error handling
-		gitCmd := exec.Command("git", "status", "--porcelain")
-		output, err := gitCmd.Output()
-		if err != nil {
-			logger.Warn("Failed to check git status", "error", err)
-			warnings = append(warnings, "Could not check git status")
-			fmt.Println(errorStyle.Render("⚠ Could not check git status"))
-		} else if len(output) > 0 {
-			warnings = append(warnings, "Uncommitted changes detected")
-			fmt.Println(errorStyle.Render("⚠ Uncommitted changes detected"))
-			if verbose {
-				fmt.Println(infoStyle.Render("  → " + strings.TrimSpace(string(output))))
-			}
-			if fix {
-				fmt.Println(infoStyle.Render("  → Commit changes with 'git add . && git commit -m \"message\"'"))
-			}
-		} else {
-			passed++
-			fmt.Println(successStyle.Render("✓ Git repository clean"))
-		}
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	fix, _ := cmd.Flags().GetBool("fix")
+	format, _ := cmd.Flags().GetString("format")
+	if err := validateFormat(format); err != nil {
+		return UserInputError("output format", err)
 	}
 
-	// Check 4: GoReleaser installed
-	total++
-	goreleaserPath, err := exec.LookPath("goreleaser")
+	enable, _ := cmd.Flags().GetStringSlice("enable")
+	disable, _ := cmd.Flags().GetStringSlice("disable")
+	rules, err := wizard.SelectRules(wizard.Rules(), enable, disable)
 	if err != nil {
-		issues = append(issues, "GoReleaser not installed")
-		fmt.Println(errorStyle.Render("✗ GoReleaser not installed"))
-		if fix {
-			fmt.Println(infoStyle.Render("  → Install with: go install github.com/goreleaser/goreleaser/v2@latest"))
-			fmt.Println(infoStyle.Render("  → Or download from: https://goreleaser.com/install/"))
-		}
-		logger.Debug("GoReleaser dependency check", "error", err)
-	} else {
-		passed++
-		fmt.Println(successStyle.Render("✓ GoReleaser installed"))
-		if verbose {
-			fmt.Println(infoStyle.Render("  → " + goreleaserPath))
-		}
-
-		// Check 5: Run goreleaser check with enhanced error handling
-		total++
-		fmt.Print("  Checking configuration... ")
-		checkCmd := exec.Command("goreleaser", "check")
-		checkOutput, checkErr := checkCmd.CombinedOutput()
-		if checkErr != nil {
-			issues = append(issues, "Configuration validation failed")
-			fmt.Println(errorStyle.Render("Failed"))
-			if verbose {
-				fmt.Println(infoStyle.Render("  → " + strings.TrimSpace(string(checkOutput))))
-			}
-			if fix {
-				fmt.Println(infoStyle.Render("  → Fix configuration issues in .goreleaser.yaml"))
-				fmt.Println(infoStyle.Render("  → Run 'goreleaser-wizard init --force' to regenerate"))
-			}
-			logger.Debug("GoReleaser config validation", "error", checkErr, "output", string(checkOutput))
-		} else {
-			passed++
-			fmt.Println(successStyle.Render("OK"))
-		}
+		return NewWizardError(ErrInvalidInput, "Invalid rule selection", err.Error(), "Run 'goreleaser-wizard validate --list-rules' to see rule IDs and categories", err)
 	}
 
-	// Check 6: Main package exists
-	if CheckFileExists(".goreleaser.yaml", false) == nil {
-		total++
-		// Parse config to find main path
-		// For simplicity, we'll check common locations
-		mainFound := false
-		commonPaths := []string{
-			"main.go",
-			"./cmd/*/main.go",
-			"./*.go",
-		}
-		for _, path := range commonPaths {
-			matches, globErr := filepath.Glob(path)
-			if globErr != nil {
-				logger.Debug("Glob pattern error", "pattern", path, "error", globErr)
-				continue
-			}
-			if len(matches) > 0 {
-				mainFound = true
-				break
-			}
-		}
-
-		if !mainFound {
-			warnings = append(warnings, "No main.go found in expected locations")
-			fmt.Println(errorStyle.Render("⚠ No main.go found"))
-			fmt.Println(infoStyle.Render("  → Make sure your main package path is correct in .goreleaser.yaml"))
-			if fix {
-				fmt.Println(infoStyle.Render("  → Create main.go or update build.main path in config"))
-			}
-		} else {
-			passed++
-			fmt.Println(successStyle.Render("✓ Main package found"))
-		}
+	if format == "text" {
+		fmt.Println(titleStyle.Render("🔍 Validating GoReleaser Configuration"))
+		fmt.Println()
 	}
 
-	// Check 7: Docker (if configured)
-	if CheckFileExists("Dockerfile", false) == nil {
-		total++
-		dockerPath, err := exec.LookPath("docker")
-		if err != nil {
-			warnings = append(warnings, "Docker not installed but Dockerfile exists")
-			fmt.Println(errorStyle.Render("⚠ Docker not installed"))
-			if fix {
-				fmt.Println(infoStyle.Render("  → Install Docker from https://docker.com/"))
-				fmt.Println(infoStyle.Render("  → Or remove Docker configuration from .goreleaser.yaml"))
-			}
-			logger.Debug("Docker dependency check", "error", err)
-		} else {
-			passed++
-			fmt.Println(successStyle.Render("✓ Docker installed"))
-			if verbose {
-				fmt.Println(infoStyle.Render("  → " + dockerPath))
-			}
-		}
-	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
 
-	// Check 8: GitHub Actions workflow
-	total++
-	workflowFound := CheckFileExists(".github/workflows/release.yml", false) == nil || 
-					 CheckFileExists(".github/workflows/release.yaml", false) == nil
-	
-	if workflowFound {
-		passed++
-		fmt.Println(successStyle.Render("✓ GitHub Actions workflow found"))
-	} else {
-		warnings = append(warnings, "No GitHub Actions workflow for releases")
-		fmt.Println(infoStyle.Render("ℹ No GitHub Actions workflow"))
-		if fix {
-			fmt.Println(infoStyle.Render("  → Run 'goreleaser-wizard init' with GitHub Actions option"))
-			fmt.Println(infoStyle.Render("  → Or manually create .github/workflows/release.yml"))
-		}
-	}
+	// Ctrl-C stops running checks and still reports what finished
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Summary
-	fmt.Println()
-	fmt.Println(titleStyle.Render("📊 Validation Summary"))
-	fmt.Printf("Checks passed: %d/%d\n", passed, total)
-	
-	if len(issues) > 0 {
-		fmt.Println()
-		fmt.Println(errorStyle.Render("❌ Critical Issues:"))
-		for _, issue := range issues {
-			fmt.Println("  • " + issue)
-		}
+	report, err := projectRoot.Validate(ctx, wizard.Options{
+		Rules:   rules,
+		Ignore:  viper.GetStringSlice("validate.ignore"),
+		Timeout: timeout,
+		Logger:  logger,
+	})
+	if err != nil {
+		return NewWizardError(ErrProjectNotFound, "Cannot read project directory", err.Error(), "Run validate from your project root or pass --dir", err)
+	}
+	if err := writeReport(os.Stdout, &report, format, verbose, fix); err != nil {
+		return NewWizardError(ErrFileWrite, "Failed to write validation report", err.Error(), "Check that stdout is writable", err)
 	}
 
-	if len(warnings) > 0 {
-		fmt.Println()
-		fmt.Println(infoStyle.Render("⚠️  Warnings:"))
-		for _, warning := range warnings {
-			fmt.Println("  • " + warning)
-		}
+	if ctx.Err() != nil {
+		return NewWizardError(ErrCancelled, "Validation cancelled", "Interrupted before every check finished", "Run validate again to check everything", ctx.Err())
 	}
 
-	// Test build suggestion
-	if len(issues) == 0 {
-		fmt.Println()
-		fmt.Println(successStyle.Render("✨ Configuration looks good!"))
-		fmt.Println()
-		fmt.Println("Next steps:")
-		fmt.Println("  1. Test build: goreleaser build --snapshot --clean")
-		fmt.Println("  2. Create tag: git tag -a v0.1.0 -m 'First release'")
-		fmt.Println("  3. Push tag: git push origin v0.1.0")
-		logger.Info("Validation completed successfully", "passed", passed, "total", total, "warnings", len(warnings))
-	} else {
-		fmt.Println()
-		fmt.Println(errorStyle.Render("⚠️  Please fix the issues above before releasing"))
-		if !fix {
-			fmt.Println()
-			fmt.Println("Run with --fix to see suggested fixes")
-		}
-		logger.Error("Validation failed", "issues", len(issues), "warnings", len(warnings), "passed", passed, "total", total)
-		os.Exit(1)
+	passed, total := report.Passed(), len(report.Checks)
+	errors, warnings := report.Count(wizard.SeverityError), len(report.Findings())-report.Count(wizard.SeverityError)
+	if errors > 0 {
+		return NewWizardError(
+			ErrValidationFailed,
+			"Validation failed",
+			fmt.Sprintf("%d issue(s), %d warning(s), %d/%d checks passed", errors, warnings, passed, total),
+			"Fix the issues above; run with --fix to see suggested fixes",
+			nil,
+		)
 	}
-}
\ No newline at end of file
+	logger.Info("Validation completed successfully", "passed", passed, "total", total, "warnings", warnings)
+	return nil
+}
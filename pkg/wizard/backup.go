@@ -0,0 +1,234 @@
+package wizard
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// BackupsDir holds a backup per run, named by the time it started
+	BackupsDir = ".goreleaser-wizard/backups"
+	// backupManifest records what a run changed, inside its backup
+	backupManifest = "backup.yaml"
+	// backupFiles holds the previous contents of the files a run replaced
+	backupFiles = "files"
+)
+
+// ErrNoBackup is returned by Restore when no run has been backed up
+var ErrNoBackup = errors.New("no backup to restore")
+
+// Backup records the files one run changed, so Restore can roll it back
+type Backup struct {
+	// ID is the backup's directory under BackupsDir
+	ID   string    `yaml:"-"`
+	Time time.Time `yaml:"time"`
+	// Replaced files had contents before the run; they are saved in the backup
+	Replaced []string `yaml:"replaced,omitempty"`
+	// Created files did not exist before the run
+	Created []string `yaml:"created,omitempty"`
+}
+
+// Dir returns the backup's directory relative to the project root
+func (b *Backup) Dir() string {
+	return path.Join(BackupsDir, b.ID)
+}
+
+// has reports whether the backup already records name
+func (b *Backup) has(name string) bool {
+	return slices.Contains(b.Replaced, name) || slices.Contains(b.Created, name)
+}
+
+// Writer writes the files of one run into a project. Files are written
+// atomically with Root.WriteFileAtomic, and before a file is first changed
+// the run's backup records it, saving its previous contents, so Restore can
+// undo the run. Files outside the project are written but not backed up. A
+// Writer is not safe for concurrent use.
+type Writer struct {
+	root   Root
+	backup *Backup
+}
+
+// NewWriter returns a Writer for a run in root; the backup is only created
+// once the run changes a file
+func NewWriter(root Root) *Writer {
+	return &Writer{root: root}
+}
+
+// Root returns the project the writer writes to
+func (w *Writer) Root() Root {
+	return w.root
+}
+
+// Backup returns the run's backup, or nil if it has not changed any file
+func (w *Writer) Backup() *Backup {
+	return w.backup
+}
+
+// WriteFile writes a file in the project, backing up what it replaces. A
+// file that already has the contents is left alone; its directory must exist.
+func (w *Writer) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	old, err := w.root.ReadFile(name)
+	existed := err == nil
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	case bytes.Equal(old, data):
+		return nil
+	}
+
+	if rel, ok := w.relative(name); ok {
+		if err := w.record(rel, old, existed); err != nil {
+			return fmt.Errorf("backing up %s: %w", w.root.Path(name), err)
+		}
+	}
+	return w.root.WriteFileAtomic(name, data, perm)
+}
+
+// relative returns name as a slash-separated path relative to the project
+// root, or false if it is outside the project
+func (w *Writer) relative(name string) (string, bool) {
+	rel, err := filepath.Rel(w.root.Path("."), w.root.Path(name))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// record adds a file to the run's backup before its first change, saving
+// its previous contents if it existed
+func (w *Writer) record(name string, old []byte, existed bool) error {
+	if w.backup == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	if w.backup.has(name) {
+		return nil
+	}
+
+	if existed {
+		saved := path.Join(w.backup.Dir(), backupFiles, name)
+		if err := w.root.MkdirAll(path.Dir(saved)); err != nil {
+			return err
+		}
+		if err := w.root.WriteFileAtomic(saved, old, 0644); err != nil {
+			return err
+		}
+		w.backup.Replaced = append(w.backup.Replaced, name)
+	} else {
+		w.backup.Created = append(w.backup.Created, name)
+	}
+
+	// Saved after every file, so a run that fails part way can still be restored
+	return w.save()
+}
+
+// save writes the run's backup manifest
+func (w *Writer) save() error {
+	data, err := yaml.Marshal(w.backup)
+	if err != nil {
+		return err
+	}
+	return w.root.WriteFileAtomic(path.Join(w.backup.Dir(), backupManifest), data, 0644)
+}
+
+// start creates the run's backup directory
+func (w *Writer) start() error {
+	now := time.Now()
+	base := now.UTC().Format("20060102-150405")
+	id := base
+	for i := 2; w.root.Exists(path.Join(BackupsDir, id)); i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	backup := &Backup{ID: id, Time: now}
+	if err := w.root.MkdirAll(backup.Dir()); err != nil {
+		return err
+	}
+
+	// Backups are local state; keep them out of version control
+	ignore := path.Join(BackupsDir, ".gitignore")
+	if !w.root.Exists(ignore) {
+		if err := w.root.WriteFile(ignore, []byte("*\n"), 0644); err != nil {
+			return err
+		}
+	}
+	w.backup = backup
+	return nil
+}
+
+// Backups lists the project's backups, newest first
+func (r Root) Backups() ([]Backup, error) {
+	entries, err := r.ReadDir(BackupsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		backup := Backup{ID: entry.Name()}
+		data, err := r.ReadFile(path.Join(backup.Dir(), backupManifest))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, &backup); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", r.Path(path.Join(backup.Dir(), backupManifest)), err)
+		}
+		backups = append(backups, backup)
+	}
+	slices.SortFunc(backups, func(a, b Backup) int {
+		return cmp.Or(b.Time.Compare(a.Time), strings.Compare(b.ID, a.ID))
+	})
+	return backups, nil
+}
+
+// Restore rolls back the most recent run: files it replaced get their
+// previous contents back and files it created are removed. The backup is
+// deleted afterwards, so restoring again rolls back the run before. If
+// restoring fails the backup is kept and Restore can be retried.
+func (r Root) Restore() (*Backup, error) {
+	backups, err := r.Backups()
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, ErrNoBackup
+	}
+	backup := &backups[0]
+
+	for _, name := range backup.Replaced {
+		data, err := r.ReadFile(path.Join(backup.Dir(), backupFiles, name))
+		if err != nil {
+			return nil, err
+		}
+		if err := r.MkdirAll(path.Dir(name)); err != nil {
+			return nil, err
+		}
+		if err := r.WriteFileAtomic(name, data, 0644); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range backup.Created {
+		if err := r.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return backup, r.RemoveAll(backup.Dir())
+}
@@ -0,0 +1,118 @@
+package wizard
+
+import (
+	"errors"
+	"os"
+	"path"
+	"slices"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	root := memRoot(t, map[string]string{ConfigFile: "old\n"})
+	if err := root.WriteFileAtomic(ConfigFile, []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := root.ReadFile(ConfigFile); string(data) != "new\n" {
+		t.Errorf("ReadFile() = %q, want the new contents", data)
+	}
+
+	// A failed rename leaves the target alone and no temporary file behind
+	failing := Root{Dir: root.Dir, FS: failFS{root.FS, func(op, name string) error {
+		if op == "rename" {
+			return errors.New("permission denied")
+		}
+		return nil
+	}}}
+	if err := failing.WriteFileAtomic(ConfigFile, []byte("newer\n"), 0644); err == nil {
+		t.Error("WriteFileAtomic() should fail when the rename does")
+	}
+	if data, _ := root.ReadFile(ConfigFile); string(data) != "new\n" {
+		t.Errorf("ReadFile() = %q after a failed write, want the previous contents", data)
+	}
+	if entries, _ := root.ReadDir("."); len(entries) != 1 {
+		t.Errorf("WriteFileAtomic() left %v behind", entries)
+	}
+
+	// Replaced files keep their permissions
+	disk := NewRoot(t.TempDir())
+	if err := disk.WriteFile("build.sh", []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := disk.WriteFileAtomic("build.sh", []byte("#!/bin/sh\nexit 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(disk.Path("build.sh")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("Stat() = %v, %v, want mode 0755 kept", info, err)
+	}
+}
+
+func TestWriterBackup(t *testing.T) {
+	root := memRoot(t, map[string]string{
+		ConfigFile: "original config\n",
+		"LICENSE":  "MIT\n",
+	})
+
+	w := NewWriter(root)
+	if err := w.WriteFile("LICENSE", []byte("MIT\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if w.Backup() != nil || root.Exists(BackupsDir) {
+		t.Fatal("Writing unchanged contents started a backup")
+	}
+
+	write := func(w *Writer, name, content string) {
+		t.Helper()
+		if err := root.MkdirAll(path.Dir(name)); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(w, ConfigFile, "first run\n")
+	write(w, ConfigFile, "first run, again\n")
+	write(w, WorkflowFile, "name: Release\n")
+
+	backup := w.Backup()
+	if !slices.Equal(backup.Replaced, []string{ConfigFile}) || !slices.Equal(backup.Created, []string{WorkflowFile}) {
+		t.Fatalf("Backup() = %+v", backup)
+	}
+	if data, _ := root.ReadFile(path.Join(backup.Dir(), backupFiles, ConfigFile)); string(data) != "original config\n" {
+		t.Errorf("Saved %s = %q, want the contents before the run", ConfigFile, data)
+	}
+	if !root.Exists(path.Join(BackupsDir, ".gitignore")) {
+		t.Error("Backups are not ignored by git")
+	}
+
+	// A second run in the same second gets its own backup
+	second := NewWriter(root)
+	write(second, ConfigFile, "second run\n")
+	if second.Backup().ID == backup.ID {
+		t.Errorf("Both runs use backup %s", backup.ID)
+	}
+	if backups, err := root.Backups(); err != nil || len(backups) != 2 || backups[0].ID != second.Backup().ID {
+		t.Fatalf("Backups() = %+v, %v, want the second run first", backups, err)
+	}
+
+	restored, err := root.Restore()
+	if err != nil || restored.ID != second.Backup().ID {
+		t.Fatalf("Restore() = %+v, %v, want the second run", restored, err)
+	}
+	if data, _ := root.ReadFile(ConfigFile); string(data) != "first run, again\n" {
+		t.Errorf("After one restore %s = %q", ConfigFile, data)
+	}
+
+	if _, err := root.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := root.ReadFile(ConfigFile); string(data) != "original config\n" {
+		t.Errorf("After two restores %s = %q", ConfigFile, data)
+	}
+	if root.Exists(WorkflowFile) {
+		t.Errorf("Restore() kept %s created by the run", WorkflowFile)
+	}
+	if _, err := root.Restore(); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("Restore() with no backups = %v, want ErrNoBackup", err)
+	}
+}
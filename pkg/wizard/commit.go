@@ -0,0 +1,229 @@
+package wizard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrConflict is returned by Commit when files are in the way of the change
+var ErrConflict = errors.New("conflicting files")
+
+// FileStatus is what Commit did with a file
+type FileStatus string
+
+const (
+	FileCreated   FileStatus = "created"
+	FileReplaced  FileStatus = "replaced"
+	FileUnchanged FileStatus = "unchanged"
+)
+
+// change is one file of a Commit, staged in a temporary file beside its
+// target until every file is ready
+type change struct {
+	// name is slash-separated and relative to the project root
+	name    string
+	data    []byte
+	perm    fs.FileMode
+	old     []byte
+	existed bool
+	// tmp is the OS path the new contents are staged in
+	tmp string
+}
+
+// Commit writes files, keyed by path relative to the project root, as one
+// change. It first checks every path: a file or directory in the way is a
+// conflict, and so is an existing file with other contents unless
+// overwrite is set; all conflicts are reported together in an ErrConflict
+// error. It then stages every file next to its target, so unwritable paths
+// fail before anything is replaced, and finally renames them into place,
+// backing up each file like WriteFile. If any step fails the files already
+// written are put back, so the project is left as it was. Files that
+// already have their contents are left alone. On success it returns the
+// status of each file, keyed like files.
+func (w *Writer) Commit(files map[string][]byte, overwrite bool) (map[string]FileStatus, error) {
+	changes, statuses, err := w.check(files, overwrite)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := w.stage(changes)
+	if err != nil {
+		w.discard(changes, created)
+		return nil, err
+	}
+
+	// Remember where the backup stood, to drop what this commit added
+	fresh := w.backup == nil
+	var replaced, added int
+	if !fresh {
+		replaced, added = len(w.backup.Replaced), len(w.backup.Created)
+	}
+
+	fsys := w.root.fsys()
+	for i, c := range changes {
+		err := w.record(c.name, c.old, c.existed)
+		if err == nil {
+			err = fsys.Rename(c.tmp, w.root.Path(c.name))
+		}
+		if err == nil {
+			c.tmp = ""
+			continue
+		}
+
+		err = fmt.Errorf("writing %s: %w", w.root.Path(c.name), err)
+		if rollbackErr := w.rollback(changes[:i], fresh, replaced, added); rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("rolling back: %w", rollbackErr))
+		}
+		w.discard(changes, created)
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// check turns files into changes and statuses, reporting every path that
+// is in the way
+func (w *Writer) check(files map[string][]byte, overwrite bool) ([]*change, map[string]FileStatus, error) {
+	var changes []*change
+	var conflicts []string
+	statuses := map[string]FileStatus{}
+	seen := map[string]string{}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		rel, ok := w.relative(name)
+		if !ok || rel == "." {
+			conflicts = append(conflicts, name+" is not a file in the project")
+			continue
+		}
+		if other, ok := seen[rel]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s and %s are the same file", other, name))
+			continue
+		}
+		seen[rel] = name
+
+		if dir := w.blocked(path.Dir(rel)); dir != "" {
+			conflicts = append(conflicts, w.root.Path(dir)+" is not a directory")
+			continue
+		}
+
+		c := &change{name: rel, data: files[name], perm: 0644}
+		info, err := w.root.Stat(rel)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, c)
+			statuses[name] = FileCreated
+			continue
+		case err != nil:
+			return nil, nil, err
+		case info.IsDir():
+			conflicts = append(conflicts, w.root.Path(rel)+" is a directory")
+			continue
+		}
+
+		if c.old, err = w.root.ReadFile(rel); err != nil {
+			return nil, nil, err
+		}
+		if bytes.Equal(c.old, c.data) {
+			statuses[name] = FileUnchanged
+			continue
+		}
+		if !overwrite {
+			conflicts = append(conflicts, w.root.Path(rel)+" already exists")
+			continue
+		}
+		c.perm, c.existed = info.Mode().Perm(), true
+		changes = append(changes, c)
+		statuses[name] = FileReplaced
+	}
+
+	if len(conflicts) > 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrConflict, strings.Join(conflicts, "; "))
+	}
+	return changes, statuses, nil
+}
+
+// blocked returns the first ancestor of dir, itself included, that exists
+// but is not a directory, or "" if the directory can be created
+func (w *Writer) blocked(dir string) string {
+	var ancestors []string
+	for ; dir != "."; dir = path.Dir(dir) {
+		ancestors = append(ancestors, dir)
+	}
+	for _, dir := range slices.Backward(ancestors) {
+		info, err := w.root.Stat(dir)
+		if err != nil {
+			return ""
+		}
+		if !info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// stage writes each change to a temporary file beside its target, creating
+// directories as needed; it returns the directories it created
+func (w *Writer) stage(changes []*change) ([]string, error) {
+	var created []string
+	fsys := w.root.fsys()
+	for _, c := range changes {
+		for dir := path.Dir(c.name); dir != "." && !w.root.Exists(dir); dir = path.Dir(dir) {
+			created = append(created, dir)
+		}
+		if err := w.root.MkdirAll(path.Dir(c.name)); err != nil {
+			return created, fmt.Errorf("creating %s: %w", w.root.Path(path.Dir(c.name)), err)
+		}
+		c.tmp = w.root.tempPath(c.name)
+		if err := fsys.WriteFile(c.tmp, c.data, c.perm); err != nil {
+			return created, fmt.Errorf("writing %s: %w", w.root.Path(c.name), err)
+		}
+	}
+	return created, nil
+}
+
+// rollback puts back the files of committed changes and drops them from
+// the run's backup, which is removed if the commit started it
+func (w *Writer) rollback(committed []*change, fresh bool, replaced, added int) error {
+	var errs []error
+	for _, c := range slices.Backward(committed) {
+		if c.existed {
+			errs = append(errs, w.root.WriteFileAtomic(c.name, c.old, c.perm))
+		} else {
+			errs = append(errs, w.root.Remove(c.name))
+		}
+	}
+
+	switch {
+	case w.backup == nil:
+	case fresh:
+		errs = append(errs, w.root.RemoveAll(w.backup.Dir()))
+		w.backup = nil
+	default:
+		for _, name := range w.backup.Replaced[replaced:] {
+			errs = append(errs, w.root.Remove(path.Join(w.backup.Dir(), backupFiles, name)))
+		}
+		w.backup.Replaced = w.backup.Replaced[:replaced]
+		w.backup.Created = w.backup.Created[:added]
+		errs = append(errs, w.save())
+	}
+	return errors.Join(errs...)
+}
+
+// discard removes staged files that were not renamed into place and the
+// directories staging created, as far as they are empty
+func (w *Writer) discard(changes []*change, created []string) {
+	fsys := w.root.fsys()
+	for _, c := range changes {
+		if c.tmp != "" {
+			fsys.Remove(c.tmp)
+		}
+	}
+	slices.SortFunc(created, func(a, b string) int { return strings.Count(b, "/") - strings.Count(a, "/") })
+	for _, dir := range created {
+		w.root.Remove(dir)
+	}
+}
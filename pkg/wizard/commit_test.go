@@ -0,0 +1,187 @@
+package wizard
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// failFS fails the operations fail returns an error for
+type failFS struct {
+	FS
+	fail func(op, name string) error
+}
+
+func (f failFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := f.fail("write", name); err != nil {
+		return err
+	}
+	return f.FS.WriteFile(name, data, perm)
+}
+
+func (f failFS) Rename(oldname, newname string) error {
+	if err := f.fail("rename", oldname); err != nil {
+		return err
+	}
+	return f.FS.Rename(oldname, newname)
+}
+
+// snapshot returns every file under root with its contents
+func snapshot(t *testing.T, root Root) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	var walk func(dir string)
+	walk = func(dir string) {
+		entries, err := root.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			name := filepath.ToSlash(filepath.Join(dir, entry.Name()))
+			if entry.IsDir() {
+				files[name+"/"] = ""
+				walk(name)
+				continue
+			}
+			data, err := root.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[name] = string(data)
+		}
+	}
+	walk(".")
+	return files
+}
+
+func TestCommit(t *testing.T) {
+	root := memRoot(t, map[string]string{ConfigFile: "old\n", "LICENSE": "MIT\n"})
+	w := NewWriter(root)
+
+	files := map[string][]byte{
+		ConfigFile:   []byte("new\n"),
+		WorkflowFile: []byte("name: Release\n"),
+		"LICENSE":    []byte("MIT\n"),
+	}
+	if _, err := w.Commit(files, false); !errors.Is(err, ErrConflict) || !strings.Contains(err.Error(), ConfigFile) {
+		t.Fatalf("Commit() without overwrite = %v, want a conflict on %s", err, ConfigFile)
+	}
+	if root.Exists(WorkflowFile) || w.Backup() != nil {
+		t.Fatal("Commit() wrote files despite a conflict")
+	}
+
+	statuses, err := w.Commit(files, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]FileStatus{ConfigFile: FileReplaced, WorkflowFile: FileCreated, "LICENSE": FileUnchanged}
+	if !maps.Equal(statuses, want) {
+		t.Errorf("Commit() = %v, want %v", statuses, want)
+	}
+	if data, _ := root.ReadFile(ConfigFile); string(data) != "new\n" || !root.Exists(WorkflowFile) {
+		t.Error("Commit() did not write every file")
+	}
+	backup := w.Backup()
+	if !slices.Equal(backup.Replaced, []string{ConfigFile}) || !slices.Equal(backup.Created, []string{WorkflowFile}) {
+		t.Errorf("Backup() = %+v, want LICENSE left alone", backup)
+	}
+}
+
+func TestCommitConflicts(t *testing.T) {
+	root := memRoot(t, map[string]string{".github": "not a directory", "docs/index.md": ""})
+	before := snapshot(t, root)
+
+	_, err := NewWriter(root).Commit(map[string][]byte{
+		WorkflowFile:     nil,
+		"docs":           nil,
+		"a.txt":          nil,
+		"./a.txt":        nil,
+		"../outside.txt": nil,
+	}, true)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Commit() = %v, want ErrConflict", err)
+	}
+	for _, want := range []string{".github is not a directory", "docs is a directory", "are the same file", "../outside.txt is not a file in the project"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Commit() error %q does not report %q", err, want)
+		}
+	}
+	if after := snapshot(t, root); !maps.Equal(before, after) {
+		t.Errorf("Commit() changed the project: %v", after)
+	}
+}
+
+func TestCommitRollback(t *testing.T) {
+	for _, op := range []string{"write", "rename"} {
+		t.Run(op, func(t *testing.T) {
+			root := memRoot(t, map[string]string{ConfigFile: "old\n"})
+			before := snapshot(t, root)
+
+			// Fail the last file, after the others are staged or written
+			failing := root
+			failing.FS = failFS{root.FS, func(failOp, name string) error {
+				if failOp == op && strings.Contains(name, "zz.txt") {
+					return errors.New("disk full")
+				}
+				return nil
+			}}
+			w := NewWriter(failing)
+			_, err := w.Commit(map[string][]byte{
+				ConfigFile:   []byte("new\n"),
+				WorkflowFile: []byte("name: Release\n"),
+				"zz.txt":     []byte("last\n"),
+			}, true)
+			if err == nil || !strings.Contains(err.Error(), "disk full") {
+				t.Fatalf("Commit() = %v, want the injected error", err)
+			}
+			if w.Backup() != nil {
+				t.Errorf("Backup() = %+v after a rolled back commit", w.Backup())
+			}
+
+			after := snapshot(t, root)
+			delete(after, ".goreleaser-wizard/")
+			delete(after, ".goreleaser-wizard/backups/")
+			delete(after, ".goreleaser-wizard/backups/.gitignore")
+			if !maps.Equal(before, after) {
+				t.Errorf("Commit() left %v, want %v", after, before)
+			}
+		})
+	}
+}
+
+func TestCommitRollbackKeepsEarlierBackup(t *testing.T) {
+	root := memRoot(t, map[string]string{ConfigFile: "old\n", "LICENSE": "MIT\n"})
+	var failing bool
+	w := NewWriter(Root{Dir: root.Dir, FS: failFS{root.FS, func(op, name string) error {
+		if failing && op == "rename" && strings.HasSuffix(name, ".tmp") && strings.Contains(name, "release.yml") {
+			return errors.New("permission denied")
+		}
+		return nil
+	}}})
+	if err := w.WriteFile("LICENSE", []byte("Apache\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	failing = true
+	if _, err := w.Commit(map[string][]byte{ConfigFile: []byte("new\n"), WorkflowFile: nil}, true); err == nil {
+		t.Fatal("Commit() should fail")
+	}
+
+	backup := w.Backup()
+	if backup == nil || !slices.Equal(backup.Replaced, []string{"LICENSE"}) || len(backup.Created) != 0 {
+		t.Fatalf("Backup() = %+v, want only the earlier LICENSE write", backup)
+	}
+	if root.Exists(filepath.Join(backup.Dir(), backupFiles, ConfigFile)) {
+		t.Error("Rolled back file still saved in the backup")
+	}
+	if _, err := root.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := root.ReadFile("LICENSE"); string(data) != "MIT\n" {
+		t.Errorf("Restore() left LICENSE = %q", data)
+	}
+}
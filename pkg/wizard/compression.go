@@ -0,0 +1,85 @@
+package wizard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// compressionFormats maps the Compression answer to a GoReleaser archive format
+var compressionFormats = map[string]string{
+	"none": "binary",
+	"gzip": "tar.gz",
+	"zstd": "tar.zst",
+	"xz":   "tar.xz",
+	"zip":  "zip",
+}
+
+// tarExtractFlags are the tar flags that unpack each tar-based archive format
+var tarExtractFlags = map[string]string{
+	"tar.gz":  "-xz",
+	"tar.zst": "--zstd -x",
+	"tar.xz":  "-xJ",
+}
+
+// CompressionOptions lists the Compression answers in display order
+var CompressionOptions = []string{"none", "gzip", "zstd", "xz", "zip"}
+
+// upxSupported lists the goarch values UPX can compress for each goos.
+// UPX no longer supports macOS binaries, so darwin is intentionally absent.
+var upxSupported = map[string][]string{
+	"linux":   {"amd64", "386", "arm", "arm64", "mips", "mipsle", "ppc64le"},
+	"windows": {"amd64", "386"},
+}
+
+// UPXTarget is one upx entry limited to a goos and its supported architectures
+type UPXTarget struct {
+	Goos   string
+	Goarch []string
+}
+
+// archiveFormat returns the archive format for a Compression answer, defaulting to tar.gz
+func archiveFormat(compression string) string {
+	if format, ok := compressionFormats[compression]; ok {
+		return format
+	}
+	return "tar.gz"
+}
+
+// tarExtract returns the tar flags that unpack an archive format from
+// stdin, or "" if the format is not a tarball
+func tarExtract(format string) string {
+	return tarExtractFlags[format]
+}
+
+// ValidateCompression checks that the Compression answer is known
+func ValidateCompression(compression string) error {
+	if compression == "" {
+		return nil
+	}
+	if _, ok := compressionFormats[compression]; !ok {
+		return fmt.Errorf("invalid compression %q (valid: %s)", compression, strings.Join(CompressionOptions, ", "))
+	}
+	return nil
+}
+
+// upxTargets returns the configured goos/goarch pairs UPX can handle
+func upxTargets(config *ProjectConfig) []UPXTarget {
+	var targets []UPXTarget
+	for _, goos := range config.Platforms {
+		supported, ok := upxSupported[goos]
+		if !ok {
+			continue
+		}
+		var arches []string
+		for _, goarch := range config.Architectures {
+			if slices.Contains(supported, goarch) {
+				arches = append(arches, goarch)
+			}
+		}
+		if len(arches) > 0 {
+			targets = append(targets, UPXTarget{Goos: goos, Goarch: arches})
+		}
+	}
+	return targets
+}
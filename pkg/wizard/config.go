@@ -0,0 +1,85 @@
+// Package wizard is the core of goreleaser-wizard: it detects Go projects,
+// renders .goreleaser.yaml and the GitHub Actions release workflow, and
+// validates release setups. The goreleaser-wizard command is a thin layer of
+// forms and flags over this package.
+package wizard
+
+import "errors"
+
+// Files written by Render, as slash-separated paths relative to the project root
+const (
+	ConfigFile   = ".goreleaser.yaml"
+	WorkflowFile = ".github/workflows/release.yml"
+)
+
+// DefaultLicense is used when no license is configured or detected
+const DefaultLicense = "MIT"
+
+var (
+	// ErrInvalidConfig is returned by Render for incomplete configurations
+	ErrInvalidConfig = errors.New("invalid project configuration")
+
+	// ErrTemplate is returned by Render when a template fails
+	ErrTemplate = errors.New("template rendering failed")
+)
+
+// ProjectConfig holds all the configuration options
+type ProjectConfig struct {
+	// Basic Info
+	ProjectName        string
+	ProjectDescription string
+	ProjectType        string
+	BinaryName         string
+	MainPath           string
+	License            string
+
+	// Build Options
+	Platforms      []string
+	Architectures  []string
+	CGOEnabled     bool
+	BuildTags      []string
+	LDFlags        bool
+	GoARM          []string
+	GoAMD64        []string
+	GoMIPS         []string
+	BuildOverrides []BuildOverride
+	PostBuildHooks []string
+
+	// Release Options
+	GitProvider    string
+	DockerEnabled  bool
+	DockerRegistry string
+	Signing        bool
+	Homebrew       bool
+	Snap           bool
+	SBOM           bool
+
+	// GitHub Actions
+	GenerateActions bool
+	ActionsOn       []string
+
+	// Advanced
+	Minimal           bool
+	ProVersion        bool
+	Compression       string
+	UPX               bool
+	UniversalBinaries bool
+	Hooks             []string
+	SkipTests         bool
+
+	// Pro Options
+	NFPMFormats     []string
+	Scoop           bool
+	ChangelogGroups bool
+	SourceArchive   bool
+	Nightly         bool
+	Partial         bool
+	MonorepoPrefix  string
+	MonorepoDir     string
+	BeforePublish   []string
+	TemplatedFiles  []TemplatedFile
+	Includes        []string
+
+	// Template pack answers, keyed by question ID
+	Custom map[string]string
+}
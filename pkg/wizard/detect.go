@@ -0,0 +1,128 @@
+package wizard
+
+import "strings"
+
+// licenseFiles lists the file names checked for an existing license, in order
+var licenseFiles = []string{
+	"LICENSE",
+	"LICENSE.md",
+	"LICENSE.txt",
+	"LICENCE",
+	"COPYING",
+}
+
+// licenseSignatures identifies well-known license texts by phrases they contain
+var licenseSignatures = []struct {
+	spdx    string
+	phrases []string
+}{
+	{"EUPL-1.2", []string{"European Union Public Licence v. 1.2"}},
+	{"Apache-2.0", []string{"Apache License", "Version 2.0"}},
+	{"MPL-2.0", []string{"Mozilla Public License Version 2.0"}},
+	{"AGPL-3.0", []string{"GNU AFFERO GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"GPL-3.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"GPL-2.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 2"}},
+	{"BSD-3-Clause", []string{"Redistribution and use in source and binary forms", "Neither the name"}},
+	{"BSD-2-Clause", []string{"Redistribution and use in source and binary forms"}},
+	{"ISC", []string{"Permission to use, copy, modify, and/or distribute this software"}},
+	{"MIT", []string{"Permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"This is free and unencumbered software released into the public domain"}},
+}
+
+// Detect inspects the Go project in dir on the local filesystem; see Root.Detect
+func Detect(dir string) (ProjectConfig, error) {
+	return NewRoot(dir).Detect()
+}
+
+// Detect inspects the project and returns what it can infer: the project
+// name from go.mod, the main package path, the binary name and the
+// license. Missing files are not an error, but the root must be a directory.
+func (r Root) Detect() (ProjectConfig, error) {
+	var config ProjectConfig
+
+	if err := r.Check(); err != nil {
+		return config, err
+	}
+
+	// Project name is the last element of the module path
+	if data, err := r.ReadFile("go.mod"); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, "module ") {
+				module := strings.TrimSpace(strings.TrimPrefix(line, "module "))
+				parts := strings.Split(module, "/")
+				config.ProjectName = parts[len(parts)-1]
+				break
+			}
+		}
+	}
+
+	// Main package: ./main.go, then cmd/<project>, then the first cmd/* with a main.go
+	if r.Exists("main.go") {
+		config.MainPath = "."
+		config.ProjectType = "CLI Application"
+	} else if config.ProjectName != "" && r.Exists("cmd/"+config.ProjectName+"/main.go") {
+		config.MainPath = "./cmd/" + config.ProjectName
+		config.ProjectType = "CLI Application"
+	} else if entries, err := r.ReadDir("cmd"); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && r.Exists("cmd/"+entry.Name()+"/main.go") {
+				config.MainPath = "./cmd/" + entry.Name()
+				config.BinaryName = entry.Name()
+				config.ProjectType = "CLI Application"
+				break
+			}
+		}
+	}
+
+	// Default binary name
+	if config.BinaryName == "" {
+		config.BinaryName = config.ProjectName
+	}
+
+	config.License, _ = r.DetectLicense()
+	return config, nil
+}
+
+// DetectLicense identifies the license of the project in dir on the local
+// filesystem; see Root.DetectLicense
+func DetectLicense(dir string) (spdxID, file string) {
+	return NewRoot(dir).DetectLicense()
+}
+
+// DetectLicense looks for an existing license file in the project and
+// identifies its SPDX ID. The ID is empty when a file exists but its license
+// is not recognized; file is the name of the license file relative to the root.
+func (r Root) DetectLicense() (spdxID, file string) {
+	for _, name := range licenseFiles {
+		data, err := r.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		return IdentifyLicense(string(data)), name
+	}
+	return "", ""
+}
+
+// IdentifyLicense returns the SPDX ID for a license text, preferring an
+// explicit SPDX-License-Identifier line over phrase matching
+func IdentifyLicense(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if _, id, found := strings.Cut(line, "SPDX-License-Identifier:"); found {
+			return strings.TrimSpace(id)
+		}
+	}
+
+	for _, signature := range licenseSignatures {
+		matched := true
+		for _, phrase := range signature.phrases {
+			if !strings.Contains(text, phrase) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return signature.spdx
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(dir string) error
+		expected ProjectConfig
+	}{
+		{
+			name: "simple_project",
+			setup: func(dir string) error {
+				// Create go.mod
+				goMod := `module github.com/user/myapp
+go 1.21`
+				if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+					return err
+				}
+				// Create main.go
+				return os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644)
+			},
+			expected: ProjectConfig{
+				ProjectName: "myapp",
+				MainPath:    ".",
+				BinaryName:  "myapp",
+				ProjectType: "CLI Application",
+			},
+		},
+		{
+			name: "cmd_structure",
+			setup: func(dir string) error {
+				// Create go.mod
+				goMod := `module github.com/user/complexapp
+go 1.21`
+				if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+					return err
+				}
+				// Create cmd/complexapp/main.go
+				if err := os.MkdirAll(filepath.Join(dir, "cmd", "complexapp"), 0755); err != nil {
+					return err
+				}
+				return os.WriteFile(filepath.Join(dir, "cmd", "complexapp", "main.go"), []byte("package main"), 0644)
+			},
+			expected: ProjectConfig{
+				ProjectName: "complexapp",
+				MainPath:    "./cmd/complexapp",
+				BinaryName:  "complexapp",
+				ProjectType: "CLI Application",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := tt.setup(dir); err != nil {
+				t.Fatalf("Setup failed: %v", err)
+			}
+
+			config, err := Detect(dir)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+
+			// Check results
+			if config.ProjectName != tt.expected.ProjectName {
+				t.Errorf("ProjectName = %q, want %q", config.ProjectName, tt.expected.ProjectName)
+			}
+			if config.MainPath != tt.expected.MainPath {
+				t.Errorf("MainPath = %q, want %q", config.MainPath, tt.expected.MainPath)
+			}
+			if config.BinaryName != tt.expected.BinaryName {
+				t.Errorf("BinaryName = %q, want %q", config.BinaryName, tt.expected.BinaryName)
+			}
+			if config.ProjectType != tt.expected.ProjectType {
+				t.Errorf("ProjectType = %q, want %q", config.ProjectType, tt.expected.ProjectType)
+			}
+		})
+	}
+}
+
+func TestDetectMissingDir(t *testing.T) {
+	if _, err := Detect(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for a missing directory")
+	}
+}
+
+func TestIdentifyLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "spdx_header",
+			text:     "// SPDX-License-Identifier: MPL-2.0\nwhatever",
+			expected: "MPL-2.0",
+		},
+		{
+			name:     "mit_text",
+			text:     "MIT License\n\nPermission is hereby granted, free of charge, to any person",
+			expected: "MIT",
+		},
+		{
+			name:     "bsd3_text",
+			text:     "Redistribution and use in source and binary forms ... Neither the name of the copyright holder",
+			expected: "BSD-3-Clause",
+		},
+		{
+			name:     "unknown_text",
+			text:     "All rights reserved.",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdentifyLicense(tt.text); got != tt.expected {
+				t.Errorf("IdentifyLicense() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectLicense(t *testing.T) {
+	dir := t.TempDir()
+	if id, file := DetectLicense(dir); id != "" || file != "" {
+		t.Errorf("DetectLicense(empty) = %q, %q", id, file)
+	}
+
+	text := "Apache License\nVersion 2.0, January 2004\n"
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE.md"), []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if id, file := DetectLicense(dir); id != "Apache-2.0" || file != "LICENSE.md" {
+		t.Errorf("DetectLicense() = %q, %q, want Apache-2.0, LICENSE.md", id, file)
+	}
+}
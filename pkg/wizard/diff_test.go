@@ -0,0 +1,37 @@
+package wizard
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	if got := Diff("same.yaml", []byte("a\nb\n"), []byte("a\nb\n")); got != "" {
+		t.Errorf("Diff() of equal content = %q, want empty", got)
+	}
+
+	old := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+	new := []byte("1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n")
+	want := `--- a/.goreleaser.yaml
++++ b/.goreleaser.yaml
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	if got := Diff(ConfigFile, old, new); got != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", got, want)
+	}
+
+	created := Diff("new.yaml", nil, []byte("a\nb\n"))
+	if want := "--- a/new.yaml\n+++ b/new.yaml\n@@ -0,0 +1,2 @@\n+a\n+b\n"; created != want {
+		t.Errorf("Diff() against nil =\n%s\nwant\n%s", created, want)
+	}
+}
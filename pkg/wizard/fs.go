@@ -0,0 +1,67 @@
+package wizard
+
+import (
+	"io/fs"
+
+	"github.com/spf13/afero"
+)
+
+// FS is the filesystem a Root reads and writes. Names are OS paths, as
+// returned by Root.Path, and errors follow the os package: a missing file
+// is fs.ErrNotExist and failures are *fs.PathError.
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Remove(name string) error
+	RemoveAll(name string) error
+	Rename(oldname, newname string) error
+	Glob(pattern string) ([]string, error)
+}
+
+// OS is the local filesystem
+var OS = NewFS(afero.NewOsFs())
+
+// NewMemFS returns an empty in-memory filesystem for tests. It is safe for
+// concurrent use, but unlike the local filesystem it creates missing parent
+// directories on write and removes directories that are not empty.
+func NewMemFS() FS {
+	return NewFS(afero.NewMemMapFs())
+}
+
+// NewFS returns an afero filesystem as an FS
+func NewFS(fsys afero.Fs) FS {
+	return aferoFS{fsys}
+}
+
+// aferoFS implements FS with the afero helpers
+type aferoFS struct {
+	fs afero.Fs
+}
+
+func (a aferoFS) Stat(name string) (fs.FileInfo, error)        { return a.fs.Stat(name) }
+func (a aferoFS) ReadFile(name string) ([]byte, error)         { return afero.ReadFile(a.fs, name) }
+func (a aferoFS) MkdirAll(name string, perm fs.FileMode) error { return a.fs.MkdirAll(name, perm) }
+func (a aferoFS) Remove(name string) error                     { return a.fs.Remove(name) }
+func (a aferoFS) RemoveAll(name string) error                  { return a.fs.RemoveAll(name) }
+func (a aferoFS) Rename(oldname, newname string) error         { return a.fs.Rename(oldname, newname) }
+func (a aferoFS) Glob(pattern string) ([]string, error)        { return afero.Glob(a.fs, pattern) }
+
+func (a aferoFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return afero.WriteFile(a.fs, name, data, perm)
+}
+
+// ReadDir implements FS, returning entries sorted by name
+func (a aferoFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	infos, err := afero.ReadDir(a.fs, name)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]fs.DirEntry, len(infos))
+	for i, info := range infos {
+		entries[i] = fs.FileInfoToDirEntry(info)
+	}
+	return entries, nil
+}
@@ -0,0 +1,153 @@
+package wizard
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// memRoot returns a project at /project in memory holding files
+func memRoot(t *testing.T, files map[string]string) Root {
+	t.Helper()
+	root := Root{Dir: "/project", FS: NewMemFS()}
+	if err := root.MkdirAll("."); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := root.MkdirAll(filepath.Dir(name)); err != nil {
+			t.Fatal(err)
+		}
+		if err := root.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// TestFS runs the same operations against the local and in-memory filesystems
+func TestFS(t *testing.T) {
+	for name, root := range map[string]Root{
+		"os":  NewRoot(t.TempDir()),
+		"mem": {Dir: "/project", FS: NewMemFS()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := root.MkdirAll(".github/workflows"); err != nil {
+				t.Fatal(err)
+			}
+			if err := root.WriteFile(WorkflowFile, []byte("name: Release\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if data, err := root.ReadFile(WorkflowFile); err != nil || string(data) != "name: Release\n" {
+				t.Errorf("ReadFile() = %q, %v", data, err)
+			}
+			if _, err := root.ReadFile(ConfigFile); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("ReadFile() of a missing file = %v, want fs.ErrNotExist", err)
+			}
+			if info, err := root.Stat(".github"); err != nil || !info.IsDir() {
+				t.Errorf("Stat(.github) = %v, %v", info, err)
+			}
+			if err := root.Check(); err != nil {
+				t.Errorf("Check() = %v", err)
+			}
+
+			entries, err := root.ReadDir(".github/workflows")
+			if err != nil || len(entries) != 1 || entries[0].Name() != "release.yml" || entries[0].IsDir() {
+				t.Errorf("ReadDir() = %v, %v", entries, err)
+			}
+			if matches, err := root.Glob(".github/*/*.yml"); err != nil || len(matches) != 1 || matches[0] != root.Path(WorkflowFile) {
+				t.Errorf("Glob() = %v, %v", matches, err)
+			}
+
+			if err := root.Rename(".github", "ci"); err != nil {
+				t.Fatal(err)
+			}
+			if root.Exists(WorkflowFile) || !root.Exists("ci/workflows/release.yml") {
+				t.Error("Rename() did not move the directory contents")
+			}
+			if err := root.Remove("ci/workflows/release.yml"); err != nil {
+				t.Fatal(err)
+			}
+			if root.Exists("ci/workflows/release.yml") {
+				t.Error("Remove() left the file")
+			}
+			if err := root.RemoveAll("ci"); err != nil || root.Exists("ci") {
+				t.Errorf("RemoveAll() = %v, want the directory gone", err)
+			}
+		})
+	}
+}
+
+func TestMemFSConcurrent(t *testing.T) {
+	root := memRoot(t, nil)
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := filepath.Join("dir", string(rune('a'+i)))
+			if err := root.MkdirAll("dir"); err != nil {
+				t.Error(err)
+			}
+			if err := root.WriteFile(name, []byte(name), 0644); err != nil {
+				t.Error(err)
+			}
+			root.ReadDir("dir")
+		}()
+	}
+	wg.Wait()
+	if entries, _ := root.ReadDir("dir"); len(entries) != 20 {
+		t.Errorf("ReadDir() = %d entries, want 20", len(entries))
+	}
+}
+
+func TestDetectInMemory(t *testing.T) {
+	root := memRoot(t, map[string]string{
+		"go.mod":            "module github.com/user/tool\n",
+		"cmd/tool/main.go":  "package main",
+		"cmd/other/main.go": "package main",
+		"LICENSE":           "Permission is hereby granted, free of charge",
+	})
+
+	config, err := root.Detect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ProjectName != "tool" || config.MainPath != "./cmd/tool" || config.License != "MIT" {
+		t.Errorf("Detect() = %+v", config)
+	}
+}
+
+func TestValidateInMemory(t *testing.T) {
+	root := memRoot(t, map[string]string{
+		"go.mod":     "module example.com/app\n",
+		"main.go":    "package main",
+		ConfigFile:   "version: 2\nbuilds:\n  - main: .\n",
+		WorkflowFile: "name: Release\n",
+		".git/HEAD":  "ref: refs/heads/main\n",
+	})
+
+	report, err := root.Validate(context.Background(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ran []string
+	for _, check := range report.Checks {
+		ran = append(ran, check.Rule)
+		if check.Rule == "git-clean" || check.Rule == "goreleaser-check" {
+			t.Errorf("%s runs commands and should not apply to an in-memory project", check.Rule)
+		}
+	}
+	for _, id := range []string{"config-exists", "config-yaml", "go-mod", "main-package", "git-repo", "release-workflow"} {
+		i := slices.Index(ran, id)
+		if i < 0 {
+			t.Errorf("%s did not run (ran %v)", id, ran)
+		} else if !report.Checks[i].Passed {
+			t.Errorf("%s failed: %+v", id, report.Checks[i])
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package wizard
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// providers are the git providers the templates know about
+var providers = []string{"GitHub", "GitLab", "Gitea", "Local Only"}
+
+// goldenBase is the configuration every golden case starts from
+func goldenBase(provider string) ProjectConfig {
+	return ProjectConfig{
+		ProjectName:        "demo",
+		ProjectDescription: "A demo application",
+		ProjectType:        "CLI Application",
+		BinaryName:         "demo",
+		MainPath:           "./cmd/demo",
+		License:            "MIT",
+		Platforms:          []string{"linux", "darwin", "windows"},
+		Architectures:      []string{"amd64", "arm64"},
+		LDFlags:            true,
+		GitProvider:        provider,
+		DockerRegistry:     "ghcr.io/acme",
+		GenerateActions:    true,
+		ActionsOn:          []string{"On version tags (v*)"},
+		Compression:        "gzip",
+	}
+}
+
+// features toggles one option each; the golden matrix and the YAML sweep
+// combine them
+var features = []struct {
+	name string
+	set  func(*ProjectConfig)
+}{
+	{"docker", func(c *ProjectConfig) { c.DockerEnabled = true }},
+	{"signing", func(c *ProjectConfig) { c.Signing = true }},
+	{"sbom", func(c *ProjectConfig) { c.SBOM = true }},
+	{"brew", func(c *ProjectConfig) { c.Homebrew = true }},
+	{"snap", func(c *ProjectConfig) { c.Snap = true }},
+	{"cgo", func(c *ProjectConfig) { c.CGOEnabled = true }},
+	{"pro", func(c *ProjectConfig) {
+		c.ProVersion = true
+		c.UniversalBinaries = true
+		c.NFPMFormats = []string{"deb", "rpm"}
+		c.Scoop = true
+		c.ChangelogGroups = true
+		c.SourceArchive = true
+		c.Nightly = true
+	}},
+}
+
+// goldenCase is one named configuration checked against testdata/golden/<name>
+type goldenCase struct {
+	name   string
+	config ProjectConfig
+}
+
+// goldenCases covers each provider bare and with every feature, and each
+// feature on its own
+func goldenCases() []goldenCase {
+	var cases []goldenCase
+	for _, provider := range providers {
+		slug := strings.ReplaceAll(strings.ToLower(provider), " ", "-")
+		cases = append(cases, goldenCase{slug, goldenBase(provider)})
+
+		all := goldenBase(provider)
+		for _, feature := range features {
+			if feature.name != "pro" {
+				feature.set(&all)
+			}
+		}
+		cases = append(cases, goldenCase{slug + "-all", all})
+
+		for _, feature := range features {
+			if feature.name == "pro" {
+				feature.set(&all)
+			}
+		}
+		cases = append(cases, goldenCase{slug + "-all-pro", all})
+	}
+	for _, feature := range features {
+		config := goldenBase("GitHub")
+		feature.set(&config)
+		cases = append(cases, goldenCase{"github-" + feature.name, config})
+	}
+
+	minimal := goldenBase("GitHub")
+	minimal.Minimal = true
+	cases = append(cases, goldenCase{"github-minimal", minimal})
+
+	// Split builds add a job to the workflow
+	partial := goldenBase("GitHub")
+	partial.ProVersion = true
+	partial.Partial = true
+	cases = append(cases, goldenCase{"github-pro-partial", partial})
+
+	// Override values that are not plain YAML scalars
+	overrides := goldenBase("GitHub")
+	overrides.BuildOverrides = []BuildOverride{{
+		Goos:    "linux",
+		Goarch:  "amd64",
+		Env:     []string{"GOFLAGS=-tags=a,b # not a comment"},
+		Flags:   []string{"-trimpath"},
+		LDFlags: []string{"-X main.note=key: value", "*glob"},
+		Tags:    []string{"&anchor", "{braced}"},
+	}}
+	cases = append(cases, goldenCase{"github-overrides", overrides})
+
+	// Every other compression changes the archives and the install footer
+	for _, compression := range []string{"none", "zip", "zstd", "xz"} {
+		config := goldenBase("GitHub")
+		config.Compression = compression
+		cases = append(cases, goldenCase{"github-compression-" + compression, config})
+	}
+
+	upx := goldenBase("GitHub")
+	upx.UPX = true
+	cases = append(cases, goldenCase{"github-upx", upx})
+
+	// Architecture variants widen the build matrix and the archive names
+	variants := goldenBase("GitHub")
+	variants.Architectures = []string{"amd64", "arm64", "arm", "mips"}
+	variants.GoARM = []string{"6", "7"}
+	variants.GoAMD64 = []string{"v1", "v3"}
+	variants.GoMIPS = []string{"hardfloat", "softfloat"}
+	cases = append(cases, goldenCase{"github-arch-variants", variants})
+	return cases
+}
+
+// goldenFile maps a rendered path to its golden file, avoiding dot files
+func goldenFile(name, file string) string {
+	return filepath.Join("testdata", "golden", name, strings.TrimPrefix(path.Base(file), "."))
+}
+
+func TestGolden(t *testing.T) {
+	goreleaser, _ := exec.LookPath("goreleaser")
+	for _, tc := range goldenCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := Render(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for file, got := range files {
+				assertYAML(t, file, got)
+
+				golden := goldenFile(tc.name, file)
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run go test ./pkg/wizard -run TestGolden -update to create it)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s differs from %s (run with -update to accept):\n%s", file, golden, Diff(file, want, got))
+				}
+			}
+
+			// OSS goreleaser rejects Pro-only keys, so only OSS configs are checked
+			if goreleaser != "" && !tc.config.ProVersion {
+				checkWithGoReleaser(t, goreleaser, files[ConfigFile])
+			}
+		})
+	}
+}
+
+// TestRenderMatrixYAML renders every combination of provider and features
+// and checks each output is well-formed YAML
+func TestRenderMatrixYAML(t *testing.T) {
+	for _, provider := range providers {
+		for mask := 0; mask < 1<<len(features); mask++ {
+			config := goldenBase(provider)
+			var names []string
+			for i, feature := range features {
+				if mask&(1<<i) != 0 {
+					feature.set(&config)
+					names = append(names, feature.name)
+				}
+			}
+			files, err := Render(config)
+			if err != nil {
+				t.Fatalf("Render(%s %v) error = %v", provider, names, err)
+			}
+			for file, content := range files {
+				var doc map[string]any
+				if err := yaml.Unmarshal(content, &doc); err != nil {
+					t.Errorf("%s with %s %v is not valid YAML: %v", file, provider, names, err)
+				}
+			}
+		}
+	}
+}
+
+// assertYAML fails unless content parses as a YAML mapping
+func assertYAML(t *testing.T, file string, content []byte) {
+	t.Helper()
+	var doc map[string]any
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		t.Errorf("%s is not valid YAML: %v", file, err)
+	} else if len(doc) == 0 {
+		t.Errorf("%s is empty", file)
+	}
+}
+
+// checkWithGoReleaser runs goreleaser check on a rendered config
+func checkWithGoReleaser(t *testing.T, goreleaser string, config []byte) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), ConfigFile)
+	if err := os.WriteFile(file, config, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goreleaser, "check", "--config", file)
+	cmd.Dir = filepath.Dir(file)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("goreleaser check failed: %v\n%s", err, out)
+	}
+}
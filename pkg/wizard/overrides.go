@@ -0,0 +1,157 @@
+package wizard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// Architecture variants supported by GoReleaser
+	GoarmOptions   = []string{"5", "6", "7"}
+	Goamd64Options = []string{"v1", "v2", "v3", "v4"}
+	GomipsOptions  = []string{"hardfloat", "softfloat"}
+)
+
+// BuildOverride holds per-target settings emitted under builds[].overrides
+type BuildOverride struct {
+	Goos    string
+	Goarch  string
+	Goarm   string
+	Goamd64 string
+	Gomips  string
+	Env     []string
+	Flags   []string
+	LDFlags []string
+	Tags    []string
+}
+
+// Target returns the goos/goarch[/variant] string identifying the override
+func (o BuildOverride) Target() string {
+	target := o.Goos + "/" + o.Goarch
+	for _, variant := range []string{o.Goarm, o.Goamd64, o.Gomips} {
+		if variant != "" {
+			target += "/" + variant
+		}
+	}
+	return target
+}
+
+// Spec formats the override in the syntax accepted by ParseBuildOverride
+func (o BuildOverride) Spec() string {
+	var settings []string
+	for _, setting := range []struct {
+		key    string
+		values []string
+	}{
+		{"env", o.Env},
+		{"flags", o.Flags},
+		{"ldflags", o.LDFlags},
+		{"tags", o.Tags},
+	} {
+		for _, value := range setting.values {
+			settings = append(settings, setting.key+"="+value)
+		}
+	}
+	return o.Target() + ":" + strings.Join(settings, ";")
+}
+
+// ParseBuildOverride parses an override spec of the form
+// "goos/goarch[/variant]:key=value[;key=value...]" where key is one of
+// env, flags, ldflags or tags. Repeated keys append to the list.
+func ParseBuildOverride(spec string) (BuildOverride, error) {
+	var override BuildOverride
+
+	target, settings, found := strings.Cut(strings.TrimSpace(spec), ":")
+	if !found || strings.TrimSpace(settings) == "" {
+		return override, fmt.Errorf("override %q must have the form goos/goarch[/variant]:key=value", spec)
+	}
+
+	parts := strings.Split(target, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return override, fmt.Errorf("override target %q must be goos/goarch or goos/goarch/variant", target)
+	}
+	override.Goos = parts[0]
+	override.Goarch = parts[1]
+
+	if len(parts) == 3 {
+		if err := override.setVariant(parts[2]); err != nil {
+			return override, err
+		}
+	}
+
+	for _, setting := range strings.Split(settings, ";") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+		key, value, found := strings.Cut(setting, "=")
+		if !found || value == "" {
+			return override, fmt.Errorf("override setting %q must be key=value", setting)
+		}
+		switch strings.ToLower(key) {
+		case "env":
+			override.Env = append(override.Env, value)
+		case "flags":
+			override.Flags = append(override.Flags, value)
+		case "ldflags":
+			override.LDFlags = append(override.LDFlags, value)
+		case "tags":
+			override.Tags = append(override.Tags, value)
+		default:
+			return override, fmt.Errorf("unknown override setting %q (use env, flags, ldflags or tags)", key)
+		}
+	}
+
+	return override, nil
+}
+
+// ParseBuildOverrides parses a list of override specs, skipping blank entries
+func ParseBuildOverrides(specs []string) ([]BuildOverride, error) {
+	var overrides []BuildOverride
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		override, err := ParseBuildOverride(spec)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+// setVariant assigns the variant to the field matching the override's goarch
+func (o *BuildOverride) setVariant(variant string) error {
+	switch {
+	case o.Goarch == "arm":
+		if !slices.Contains(GoarmOptions, variant) {
+			return fmt.Errorf("invalid goarm %q (valid: %s)", variant, strings.Join(GoarmOptions, ", "))
+		}
+		o.Goarm = variant
+	case o.Goarch == "amd64":
+		if !slices.Contains(Goamd64Options, variant) {
+			return fmt.Errorf("invalid goamd64 %q (valid: %s)", variant, strings.Join(Goamd64Options, ", "))
+		}
+		o.Goamd64 = variant
+	case strings.HasPrefix(o.Goarch, "mips"):
+		if !slices.Contains(GomipsOptions, variant) {
+			return fmt.Errorf("invalid gomips %q (valid: %s)", variant, strings.Join(GomipsOptions, ", "))
+		}
+		o.Gomips = variant
+	default:
+		return fmt.Errorf("goarch %q has no variants", o.Goarch)
+	}
+	return nil
+}
+
+// ValidateVariants checks that every selected variant is supported
+func ValidateVariants(name string, values, valid []string) error {
+	for _, value := range values {
+		if !slices.Contains(valid, value) {
+			return fmt.Errorf("invalid %s %q (valid: %s)", name, value, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package wizard
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBuildOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		wantErr  bool
+		expected BuildOverride
+	}{
+		{
+			name: "env_and_flags",
+			spec: "linux/arm64:env=CGO_ENABLED=1;flags=-trimpath",
+			expected: BuildOverride{
+				Goos:   "linux",
+				Goarch: "arm64",
+				Env:    []string{"CGO_ENABLED=1"},
+				Flags:  []string{"-trimpath"},
+			},
+		},
+		{
+			name: "amd64_variant",
+			spec: "linux/amd64/v3:ldflags=-s -w;tags=fast;tags=simd",
+			expected: BuildOverride{
+				Goos:    "linux",
+				Goarch:  "amd64",
+				Goamd64: "v3",
+				LDFlags: []string{"-s -w"},
+				Tags:    []string{"fast", "simd"},
+			},
+		},
+		{
+			name:     "arm_variant",
+			spec:     "linux/arm/7:env=GOARM=7",
+			expected: BuildOverride{Goos: "linux", Goarch: "arm", Goarm: "7", Env: []string{"GOARM=7"}},
+		},
+		{
+			name:    "missing_settings",
+			spec:    "linux/amd64",
+			wantErr: true,
+		},
+		{
+			name:    "missing_goarch",
+			spec:    "linux:env=A=B",
+			wantErr: true,
+		},
+		{
+			name:    "invalid_variant",
+			spec:    "linux/amd64/v9:env=A=B",
+			wantErr: true,
+		},
+		{
+			name:    "variant_without_support",
+			spec:    "linux/arm64/v8:env=A=B",
+			wantErr: true,
+		},
+		{
+			name:    "unknown_key",
+			spec:    "linux/amd64:cflags=-O2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBuildOverride(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBuildOverride() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseBuildOverride() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildOverrideSpecRoundTrip(t *testing.T) {
+	spec := "linux/amd64/v3:env=CGO_ENABLED=1;flags=-trimpath;tags=fast"
+	override, err := ParseBuildOverride(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := override.Spec(); got != spec {
+		t.Errorf("Spec() = %q, want %q", got, spec)
+	}
+}
@@ -0,0 +1,479 @@
+package wizard
+
+import (
+	"archive/tar"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template pack files
+const (
+	PackManifestFile = "pack.yaml"
+	LockFile         = ".goreleaser-wizard.lock"
+)
+
+// ErrPack is returned when a template pack cannot be fetched or loaded
+var ErrPack = errors.New("template pack error")
+
+// questionID restricts question IDs to names usable as .Custom.<id> in
+// templates; lowercase because config keys are case-insensitive
+var questionID = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// PackManifest is the pack.yaml at the root of a template pack
+type PackManifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Templates is the pack directory holding *.tmpl overrides, default "templates"
+	Templates string     `yaml:"templates,omitempty"`
+	Questions []Question `yaml:"questions,omitempty"`
+	Outputs   []Output   `yaml:"outputs,omitempty"`
+}
+
+// Question types
+const (
+	QuestionInput       = "input"
+	QuestionSelect      = "select"
+	QuestionMultiSelect = "multiselect"
+	QuestionConfirm     = "confirm"
+)
+
+// Question is an extra question asked by a template pack. Its answer is
+// available to templates as .Custom.<ID>: multiselect answers are
+// comma-separated and confirm answers are "true" or "false".
+type Question struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	// Type is input (the default), select, multiselect or confirm
+	Type    string   `yaml:"type,omitempty"`
+	Options []string `yaml:"options,omitempty"`
+	Default string   `yaml:"default,omitempty"`
+	// Validate is a regular expression input answers must match
+	Validate string `yaml:"validate,omitempty"`
+	// When is a template condition, e.g. `.DockerEnabled` or
+	// `eq .Custom.registry "internal"`; the question is only asked when it holds
+	When string `yaml:"when,omitempty"`
+
+	pattern *regexp.Regexp
+	when    *template.Template
+}
+
+// Asked reports whether the question applies to config
+func (q *Question) Asked(config ProjectConfig) (bool, error) {
+	if q.when == nil {
+		return true, nil
+	}
+	var out strings.Builder
+	if err := q.when.Execute(&out, config); err != nil {
+		return false, fmt.Errorf("%w: question %q: when: %w", ErrPack, q.ID, err)
+	}
+	return out.String() == "true", nil
+}
+
+// Check reports whether value is a valid answer
+func (q *Question) Check(value string) error {
+	switch q.Type {
+	case QuestionSelect:
+		if !slices.Contains(q.Options, value) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(q.Options, ", "))
+		}
+	case QuestionMultiSelect:
+		for _, item := range SplitAnswer(value) {
+			if !slices.Contains(q.Options, item) {
+				return fmt.Errorf("%q is not one of %s", item, strings.Join(q.Options, ", "))
+			}
+		}
+	case QuestionConfirm:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+	default:
+		if q.pattern != nil && !q.pattern.MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, q.Validate)
+		}
+	}
+	return nil
+}
+
+// SplitAnswer splits a comma-separated multiselect answer
+func SplitAnswer(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Output is an extra file rendered from a template. Outputs that render
+// to nothing but whitespace are skipped, so templates can opt out.
+type Output struct {
+	// Path is slash-separated and relative to the project root
+	Path     string `yaml:"path"`
+	Template string `yaml:"template"`
+}
+
+// Pack is a template pack ready to use from a local directory
+type Pack struct {
+	Dir      string
+	Manifest PackManifest
+	// Source and Commit are set for packs fetched from git
+	Source string
+	Commit string
+}
+
+// PackLock pins a git template pack to a commit
+type PackLock struct {
+	Source string `yaml:"source"`
+	Ref    string `yaml:"ref,omitempty"`
+	Commit string `yaml:"commit"`
+}
+
+// TemplatesDir returns the directory of the pack's template overrides
+func (p *Pack) TemplatesDir() string {
+	dir := p.Manifest.Templates
+	if dir == "" {
+		dir = "templates"
+	}
+	return filepath.Join(p.Dir, filepath.FromSlash(dir))
+}
+
+// ApplyDefaults fills unanswered pack questions with their defaults;
+// confirm questions default to "false"
+func (p *Pack) ApplyDefaults(config *ProjectConfig) {
+	for _, question := range p.Manifest.Questions {
+		value := question.Default
+		if value == "" && question.Type == QuestionConfirm {
+			value = "false"
+		}
+		if _, ok := config.Custom[question.ID]; ok || value == "" {
+			continue
+		}
+		if config.Custom == nil {
+			config.Custom = make(map[string]string)
+		}
+		config.Custom[question.ID] = value
+	}
+}
+
+// CheckAnswers validates the answers to the questions that apply to config.
+// Unanswered questions are skipped.
+func (p *Pack) CheckAnswers(config ProjectConfig) error {
+	for i := range p.Manifest.Questions {
+		question := &p.Manifest.Questions[i]
+		value, ok := config.Custom[question.ID]
+		if !ok {
+			continue
+		}
+		asked, err := question.Asked(config)
+		if err != nil {
+			return err
+		}
+		if !asked {
+			continue
+		}
+		if err := question.Check(value); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidConfig, question.ID, err)
+		}
+	}
+	return nil
+}
+
+// OpenPack loads the template pack in dir
+func OpenPack(dir string) (*Pack, error) {
+	data, err := NewRoot(dir).ReadFile(PackManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading manifest: %w", ErrPack, err)
+	}
+	var manifest PackManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %w", ErrPack, PackManifestFile, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrPack, PackManifestFile, err)
+	}
+	return &Pack{Dir: dir, Manifest: manifest}, nil
+}
+
+// validate checks names, question IDs and output paths
+func (m *PackManifest) validate() error {
+	if m.Name == "" {
+		return errors.New("name is required")
+	}
+	if m.Templates != "" && !filepath.IsLocal(filepath.FromSlash(m.Templates)) {
+		return fmt.Errorf("templates %q must be a relative path inside the pack", m.Templates)
+	}
+
+	ids := make(map[string]bool)
+	for i := range m.Questions {
+		question := &m.Questions[i]
+		if !questionID.MatchString(question.ID) {
+			return fmt.Errorf("question id %q must be lowercase letters, digits and underscores, not starting with a digit", question.ID)
+		}
+		if ids[question.ID] {
+			return fmt.Errorf("duplicate question id %q", question.ID)
+		}
+		ids[question.ID] = true
+		if err := question.compile(); err != nil {
+			return fmt.Errorf("question %q: %w", question.ID, err)
+		}
+	}
+
+	paths := make(map[string]bool)
+	for _, output := range m.Outputs {
+		if err := validateOutput(output); err != nil {
+			return err
+		}
+		if paths[output.Path] {
+			return fmt.Errorf("duplicate output path %q", output.Path)
+		}
+		paths[output.Path] = true
+	}
+	return nil
+}
+
+// compile checks the question's type and options and parses its
+// validation pattern and when condition
+func (q *Question) compile() error {
+	switch q.Type {
+	case "":
+		q.Type = QuestionInput
+	case QuestionInput, QuestionConfirm:
+	case QuestionSelect, QuestionMultiSelect:
+		if len(q.Options) == 0 {
+			return fmt.Errorf("%s questions need options", q.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, want input, select, multiselect or confirm", q.Type)
+	}
+	if q.Validate != "" {
+		if q.Type != QuestionInput {
+			return errors.New("validate only applies to input questions")
+		}
+		pattern, err := regexp.Compile(q.Validate)
+		if err != nil {
+			return fmt.Errorf("validate: %w", err)
+		}
+		q.pattern = pattern
+	}
+	if q.When != "" {
+		when, err := template.New(q.ID).Funcs(templateFuncs).Parse("{{if " + q.When + "}}true{{end}}")
+		if err != nil {
+			return fmt.Errorf("when: %w", err)
+		}
+		q.when = when
+	}
+	if q.Default != "" {
+		if err := q.Check(q.Default); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateOutput keeps outputs inside the project and off the built-in files
+func validateOutput(output Output) error {
+	if output.Template == "" {
+		return fmt.Errorf("output %q needs a template", output.Path)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(output.Path)) {
+		return fmt.Errorf("output path %q must be relative and inside the project", output.Path)
+	}
+	if output.Path == ConfigFile || output.Path == WorkflowFile {
+		return fmt.Errorf("output path %q is a built-in file; override its template instead", output.Path)
+	}
+	return nil
+}
+
+// IsGitSource reports whether a pack source names a git repository rather
+// than a local directory: a URL, an scp-style address or a path ending in
+// .git, optionally followed by #ref. Sources starting with - are not, so
+// they can never reach git as an option.
+func IsGitSource(source string) bool {
+	source, _, _ = strings.Cut(source, "#")
+	if strings.HasPrefix(source, "-") {
+		return false
+	}
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git")
+}
+
+// ReadPackLock reads the lock file in root
+func ReadPackLock(root Root) (PackLock, error) {
+	var lock PackLock
+	data, err := root.ReadFile(LockFile)
+	if err != nil {
+		return lock, err
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return lock, fmt.Errorf("%w: parsing %s: %w", ErrPack, LockFile, err)
+	}
+	return lock, nil
+}
+
+// Marshal renders the lock file contents
+func (l PackLock) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("# Generated by goreleaser-wizard; pins the template pack. Commit this file.\n"), data...), nil
+}
+
+// FetchPack fetches a git template pack into cacheDir and opens it at
+// commit, or at ref ("HEAD" when empty) if commit is empty. Checkouts are
+// cached by commit, so a pinned pack is only fetched once.
+func FetchPack(ctx context.Context, source, ref, commit, cacheDir string) (*Pack, error) {
+	// git would take the source for an option
+	if strings.HasPrefix(source, "-") {
+		return nil, fmt.Errorf("%w: invalid source %q", ErrPack, source)
+	}
+	sum := sha256.Sum256([]byte(source))
+	repo := filepath.Join(cacheDir, "repos", hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(repo); err != nil {
+		if err := os.MkdirAll(filepath.Dir(repo), 0755); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPack, err)
+		}
+		if _, err := git(ctx, "", "clone", "--quiet", "--bare", "--", source, repo); err != nil {
+			return nil, err
+		}
+	} else if commit == "" || !hasCommit(ctx, repo, commit) {
+		// Refs may have moved, and a pinned commit may not be fetched yet
+		if err := fetchPack(ctx, repo, source); err != nil {
+			return nil, err
+		}
+	}
+
+	if commit == "" {
+		if ref == "" {
+			ref = "HEAD"
+		}
+		commit = ref
+	}
+	// Resolve refs and abbreviated commits so checkouts are cached once per commit
+	commit, err := git(ctx, repo, "rev-parse", "--verify", "--quiet", commit+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q not found in %s", ErrPack, cmp.Or(ref, commit), source)
+	}
+
+	dir := filepath.Join(cacheDir, "checkouts", commit)
+	if _, err := os.Stat(dir); err != nil {
+		if err := checkoutPack(ctx, repo, commit, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	pack, err := OpenPack(dir)
+	if err != nil {
+		return nil, err
+	}
+	pack.Source = source
+	pack.Commit = commit
+	return pack, nil
+}
+
+// fetchPack updates the cached bare clone with the source's branches and tags
+func fetchPack(ctx context.Context, repo, source string) error {
+	_, err := git(ctx, repo, "fetch", "--quiet", "--force", "--tags", "--", source, "+refs/heads/*:refs/heads/*")
+	return err
+}
+
+// hasCommit reports whether the cached clone has commit
+func hasCommit(ctx context.Context, repo, commit string) bool {
+	_, err := git(ctx, repo, "cat-file", "-e", commit+"^{commit}")
+	return err == nil
+}
+
+// checkoutPack extracts commit into dir. Files go to a temporary directory
+// that is renamed into place, so an interrupted checkout is never reused.
+func checkoutPack(ctx context.Context, repo, commit, dir string) error {
+	archive, err := gitOutput(ctx, repo, "archive", "--format=tar", commit)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fmt.Errorf("%w: %w", ErrPack, err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), "checkout-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPack, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: reading archive: %w", ErrPack, err)
+		}
+		if !filepath.IsLocal(header.Name) {
+			continue
+		}
+		target := filepath.Join(tmp, header.Name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err == nil {
+				var data []byte
+				if data, err = io.ReadAll(reader); err == nil {
+					err = os.WriteFile(target, data, 0644)
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%w: extracting %s: %w", ErrPack, header.Name, err)
+		}
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		// Another process may have finished the same checkout first
+		if _, statErr := os.Stat(dir); statErr != nil {
+			return fmt.Errorf("%w: %w", ErrPack, err)
+		}
+	}
+	return nil
+}
+
+// git runs a git command and returns its trimmed output
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := gitOutput(ctx, dir, args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+// gitOutput runs a git command without prompting for credentials and
+// returns its raw output; failures include git's error message
+func gitOutput(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: git %s: %s", ErrPack, args[0], message)
+		}
+		return nil, fmt.Errorf("%w: git %s: %w", ErrPack, args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,308 @@
+package wizard
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePack writes files into dir, creating parent directories
+func writePack(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const testManifest = `name: acme
+questions:
+  - id: team
+    title: Owning team
+  - id: cost_center
+    title: Cost center
+    default: CC-1
+outputs:
+  - path: .acme/catalog.yaml
+    template: outputs/catalog
+`
+
+func TestOpenPack(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, map[string]string{PackManifestFile: testManifest})
+
+	pack, err := OpenPack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pack.Manifest.Name != "acme" || len(pack.Manifest.Questions) != 2 || pack.TemplatesDir() != filepath.Join(dir, "templates") {
+		t.Errorf("OpenPack() = %+v", pack)
+	}
+
+	config := ProjectConfig{Custom: map[string]string{"team": "payments"}}
+	pack.ApplyDefaults(&config)
+	if config.Custom["team"] != "payments" || config.Custom["cost_center"] != "CC-1" {
+		t.Errorf("ApplyDefaults() = %v", config.Custom)
+	}
+}
+
+func TestOpenPackInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{"missing_name", "description: x\n", "name is required"},
+		{"bad_question_id", "name: x\nquestions:\n  - id: Cost-Center\n", "question id"},
+		{"duplicate_question", "name: x\nquestions:\n  - id: a\n  - id: a\n", "duplicate question"},
+		{"output_escapes", "name: x\noutputs:\n  - path: ../x\n    template: t\n", "inside the project"},
+		{"output_builtin", "name: x\noutputs:\n  - path: .goreleaser.yaml\n    template: t\n", "built-in file"},
+		{"templates_escapes", "name: x\ntemplates: /etc\n", "inside the pack"},
+		{"not_yaml", "name: [x\n", "parsing"},
+		{"unknown_type", "name: x\nquestions:\n  - id: a\n    type: radio\n", "unknown type"},
+		{"select_without_options", "name: x\nquestions:\n  - id: a\n    type: select\n", "need options"},
+		{"bad_pattern", "name: x\nquestions:\n  - id: a\n    validate: '['\n", "validate"},
+		{"bad_when", "name: x\nquestions:\n  - id: a\n    when: 'eq (.Custom.b'\n", "when"},
+		{"invalid_default", "name: x\nquestions:\n  - id: a\n    type: select\n    options: [b]\n    default: c\n", "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePack(t, dir, map[string]string{PackManifestFile: tt.manifest})
+			_, err := OpenPack(dir)
+			if !errors.Is(err, ErrPack) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("OpenPack() error = %v, want ErrPack mentioning %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := OpenPack(t.TempDir()); !errors.Is(err, ErrPack) {
+		t.Errorf("OpenPack() without manifest = %v, want ErrPack", err)
+	}
+}
+
+const questionsManifest = `name: acme
+questions:
+  - id: cost_center
+    validate: '^CC-[0-9]+$'
+  - id: registry
+    type: select
+    options: [public, internal]
+    default: public
+  - id: registry_host
+    when: eq .Custom.registry "internal"
+    validate: '^[a-z0-9.-]+$'
+  - id: regions
+    type: multiselect
+    options: [eu, us, apac]
+  - id: sbom
+    type: confirm
+    when: .DockerEnabled
+`
+
+func TestPackQuestions(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, map[string]string{PackManifestFile: questionsManifest})
+	pack, err := OpenPack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pack.Manifest.Questions[0].Type; got != QuestionInput {
+		t.Errorf("Default question type = %q, want %q", got, QuestionInput)
+	}
+
+	tests := []struct {
+		name    string
+		custom  map[string]string
+		docker  bool
+		wantErr string
+	}{
+		{"defaults", map[string]string{}, false, ""},
+		{"confirm_default", map[string]string{}, true, ""},
+		{"valid", map[string]string{"cost_center": "CC-42", "registry": "internal", "registry_host": "registry.acme.io", "regions": "eu, us"}, true, ""},
+		{"pattern", map[string]string{"cost_center": "42"}, false, "cost_center"},
+		{"select", map[string]string{"registry": "private"}, false, "not one of"},
+		{"multiselect", map[string]string{"regions": "eu,mars"}, false, `"mars"`},
+		{"confirm", map[string]string{"sbom": "yes"}, true, "true or false"},
+		// Questions whose condition does not hold are not checked
+		{"unasked", map[string]string{"registry_host": "Not A Host", "sbom": "yes"}, false, ""},
+		{"asked", map[string]string{"registry": "internal", "registry_host": "Not A Host"}, false, "registry_host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ProjectConfig{DockerEnabled: tt.docker, Custom: tt.custom}
+			pack.ApplyDefaults(&config)
+			err := pack.CheckAnswers(config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckAnswers() = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckAnswers() = %v, want ErrInvalidConfig mentioning %s", err, tt.wantErr)
+			}
+		})
+	}
+
+	sbom := &pack.Manifest.Questions[4]
+	for docker, want := range map[bool]bool{true: true, false: false} {
+		if asked, err := sbom.Asked(ProjectConfig{DockerEnabled: docker}); err != nil || asked != want {
+			t.Errorf("Asked() with DockerEnabled=%v = %v, %v, want %v", docker, asked, err, want)
+		}
+	}
+}
+
+func TestIsGitSource(t *testing.T) {
+	for source, want := range map[string]bool{
+		"https://github.com/acme/pack": true,
+		"git@github.com:acme/pack.git": true,
+		"/srv/packs/acme.git#v1.2.0":   true,
+		"file:///srv/packs/acme":       true,
+		"./packs/acme":                 false,
+		"/srv/packs/acme":              false,
+		"--upload-pack=touch x;.git":   false,
+	} {
+		if got := IsGitSource(source); got != want {
+			t.Errorf("IsGitSource(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
+
+func TestRenderWithPackOutputs(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, map[string]string{
+		PackManifestFile:                 testManifest,
+		"templates/outputs/catalog.tmpl": `{{define "outputs/catalog"}}team: {{.Custom.team}}` + "\n{{end}}",
+		"templates/outputs/empty.tmpl":   `{{define "outputs/empty"}}{{if .DockerEnabled}}docker{{end}}` + "\n{{end}}",
+		"templates/outputs/regions.tmpl": `{{define "outputs/regions"}}{{range splitAnswer .Custom.regions}}- {{.}}` + "\n{{end}}{{end}}",
+	})
+	pack, err := OpenPack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := LoadTemplates(pack.TemplatesDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := ProjectConfig{ProjectName: "demo", BinaryName: "demo", MainPath: ".", Custom: map[string]string{"team": "payments", "regions": "eu, us"}}
+	outputs := append(pack.Manifest.Outputs, Output{Path: "docker.txt", Template: "outputs/empty"}, Output{Path: "regions.txt", Template: "outputs/regions"})
+	files, err := RenderWith(config, templates, outputs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(files[".acme/catalog.yaml"]); got != "team: payments\n" {
+		t.Errorf("catalog output = %q", got)
+	}
+	if got := string(files["regions.txt"]); got != "- eu\n- us\n" {
+		t.Errorf("regions output = %q", got)
+	}
+	if _, ok := files["docker.txt"]; ok {
+		t.Error("Output rendering only whitespace should be skipped")
+	}
+
+	if _, err := RenderWith(config, templates, Output{Path: "../escape", Template: "outputs/catalog"}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("RenderWith() with an escaping output = %v, want ErrInvalidConfig", err)
+	}
+}
+
+// gitCommit commits everything in dir and returns the commit hash
+func gitCommit(t *testing.T, dir, message string) string {
+	t.Helper()
+	for _, args := range [][]string{
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", message},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestFetchPack(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+
+	// A working repository publishes the pack to a local bare repository
+	work, remote, cache := t.TempDir(), filepath.Join(t.TempDir(), "pack.git"), t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", work).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	writePack(t, work, map[string]string{PackManifestFile: "name: acme\ndescription: v1\n"})
+	first := gitCommit(t, work, "v1")
+	if out, err := exec.Command("git", "clone", "-q", "--bare", work, remote).CombinedOutput(); err != nil {
+		t.Fatalf("git clone: %v\n%s", err, out)
+	}
+
+	pack, err := FetchPack(ctx, remote, "", "", cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pack.Commit != first || pack.Manifest.Description != "v1" || pack.Source != remote {
+		t.Errorf("FetchPack() = %+v, want v1 at %s", pack, first)
+	}
+
+	writePack(t, work, map[string]string{PackManifestFile: "name: acme\ndescription: v2\n"})
+	second := gitCommit(t, work, "v2")
+	if out, err := exec.Command("git", "-C", work, "push", "-q", remote, "HEAD:refs/heads/"+currentBranch(t, work)).CombinedOutput(); err != nil {
+		t.Fatalf("git push: %v\n%s", err, out)
+	}
+
+	// A pinned commit stays put; resolving the ref again picks up v2
+	pinned, err := FetchPack(ctx, remote, "", first[:10], cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned.Commit != first || pinned.Manifest.Description != "v1" {
+		t.Errorf("Pinned FetchPack() = %+v, want v1", pinned)
+	}
+	latest, err := FetchPack(ctx, remote, "", "", cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Commit != second || latest.Manifest.Description != "v2" {
+		t.Errorf("Latest FetchPack() = %+v, want v2 at %s", latest, second)
+	}
+
+	if _, err := FetchPack(ctx, remote, "no-such-ref", "", cache); !errors.Is(err, ErrPack) {
+		t.Errorf("FetchPack() with a missing ref = %v, want ErrPack", err)
+	}
+
+	// A source is never passed to git as an option
+	marker := filepath.Join(t.TempDir(), "injected")
+	if _, err := FetchPack(ctx, "--upload-pack=touch "+marker+";", "", "", t.TempDir()); !errors.Is(err, ErrPack) {
+		t.Errorf("FetchPack() with an option as source = %v, want ErrPack", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("FetchPack() ran the injected command")
+	}
+}
+
+// currentBranch returns the branch checked out in dir
+func currentBranch(t *testing.T, dir string) string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", dir, "symbolic-ref", "--short", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(out))
+}
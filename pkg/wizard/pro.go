@@ -0,0 +1,98 @@
+package wizard
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// proOnlyKeys lists top-level .goreleaser.yaml keys that only GoReleaser Pro understands
+var proOnlyKeys = []string{
+	"before_publish",
+	"includes",
+	"monorepo",
+	"nightly",
+	"partial",
+	"template_files",
+}
+
+// proOnlyReleaseKeys lists keys under release: that only GoReleaser Pro understands
+var proOnlyReleaseKeys = []string{
+	"templated_extra_files",
+}
+
+// TemplatedFile is a Pro templated extra file attached to the release
+type TemplatedFile struct {
+	Src string
+	Dst string
+}
+
+// ParseTemplatedFiles parses "src[:dst]" specs; dst defaults to the base name of src
+// with a trailing .tpl removed
+func ParseTemplatedFiles(specs []string) []TemplatedFile {
+	var files []TemplatedFile
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		src, dst, found := strings.Cut(spec, ":")
+		if !found || dst == "" {
+			dst = strings.TrimSuffix(filepath.Base(src), ".tpl")
+		}
+		files = append(files, TemplatedFile{Src: src, Dst: dst})
+	}
+	return files
+}
+
+// isRemoteInclude reports whether an include should be rendered as from_url
+func isRemoteInclude(include string) bool {
+	return strings.HasPrefix(include, "https://") || strings.HasPrefix(include, "http://")
+}
+
+// findProOnlyKeys returns the Pro-only keys used in a .goreleaser.yaml document
+func findProOnlyKeys(data []byte) ([]string, error) {
+	doc, err := parseConfigDoc(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+	for _, key := range proOnlyKeys {
+		if _, ok := doc[key]; ok {
+			found = append(found, key)
+		}
+	}
+
+	if release, ok := doc["release"].(map[string]any); ok {
+		for _, key := range proOnlyReleaseKeys {
+			if _, ok := release[key]; ok {
+				found = append(found, "release."+key)
+			}
+		}
+	}
+
+	sort.Strings(found)
+	return found, nil
+}
+
+// usesProDistribution reports whether any workflow in .github/workflows
+// installs the goreleaser-pro distribution
+func usesProDistribution(root Root) bool {
+	for _, pattern := range []string{".github/workflows/*.yml", ".github/workflows/*.yaml"} {
+		matches, err := root.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			data, err := root.fsys().ReadFile(match)
+			if err != nil {
+				continue
+			}
+			if strings.Contains(string(data), "goreleaser-pro") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package wizard
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFindProOnlyKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantErr  bool
+		expected []string
+	}{
+		{
+			name:     "free_config",
+			yaml:     "version: 2\nbuilds:\n  - id: app\nrelease:\n  draft: false\n",
+			expected: nil,
+		},
+		{
+			name:     "pro_keys",
+			yaml:     "version: 2\nnightly:\n  tag_name: nightly\nmonorepo:\n  tag_prefix: app/\nrelease:\n  templated_extra_files:\n    - src: a.tpl\n",
+			expected: []string{"monorepo", "nightly", "release.templated_extra_files"},
+		},
+		{
+			name:    "invalid_yaml",
+			yaml:    "version: [2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findProOnlyKeys([]byte(tt.yaml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findProOnlyKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("findProOnlyKeys() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProOnlyKeysRule(t *testing.T) {
+	root := memRoot(t, map[string]string{
+		ConfigFile: "version: 2\nnightly: # wizard:ignore pro-only-keys\n  tag_name: nightly\nrelease:\n  templated_extra_files:\n    - src: a.tpl\n",
+	})
+	rules, _ := SelectRules(Rules(), []string{"pro-only-keys"}, nil)
+	report, err := root.Validate(context.Background(), Options{Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := report.Findings()
+	if len(findings) != 1 || findings[0].Line != 5 || findings[0].Message != "Pro-only key 'release.templated_extra_files' used without GoReleaser Pro" {
+		t.Errorf("Findings = %+v, want release.templated_extra_files at line 5", findings)
+	}
+	if len(report.Suppressed) != 1 || report.Suppressed[0].Line != 2 || report.Suppressed[0].SuppressedBy != SuppressedInline {
+		t.Errorf("Suppressed = %+v, want nightly at line 2 suppressed inline", report.Suppressed)
+	}
+}
+
+func TestParseTemplatedFiles(t *testing.T) {
+	got := ParseTemplatedFiles([]string{"docs/INSTALL.md.tpl", "notes.tpl:RELEASE_NOTES.md", " "})
+	expected := []TemplatedFile{
+		{Src: "docs/INSTALL.md.tpl", Dst: "INSTALL.md"},
+		{Src: "notes.tpl", Dst: "RELEASE_NOTES.md"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ParseTemplatedFiles() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package wizard
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+)
+
+// Render renders the release files for config with the built-in templates,
+// without touching disk. The result maps ConfigFile, and WorkflowFile when
+// GenerateActions is set, to their contents. Unset GitProvider and License
+// fall back to their defaults.
+func Render(config ProjectConfig) (map[string][]byte, error) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return RenderWith(config, templates)
+}
+
+// RenderWith renders like Render using templates from LoadTemplates, plus
+// any extra outputs such as those declared by a template pack
+func RenderWith(config ProjectConfig, templates *template.Template, outputs ...Output) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	content, err := renderConfig(&config, templates)
+	if err != nil {
+		return nil, err
+	}
+	files[ConfigFile] = content
+
+	if config.GenerateActions {
+		content, err := renderWorkflow(&config, templates)
+		if err != nil {
+			return nil, err
+		}
+		files[WorkflowFile] = content
+	}
+
+	for _, output := range outputs {
+		if err := validateOutput(output); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, output.Template, &config); err != nil {
+			return nil, fmt.Errorf("%w: executing %s: %w", ErrTemplate, output.Path, err)
+		}
+		if len(bytes.TrimSpace(buf.Bytes())) > 0 {
+			files[output.Path] = buf.Bytes()
+		}
+	}
+
+	return files, nil
+}
+
+// renderConfig renders .goreleaser.yaml, filling in defaults on config
+func renderConfig(config *ProjectConfig, templates *template.Template) ([]byte, error) {
+	// Validate config before generating
+	if config.ProjectName == "" {
+		return nil, fmt.Errorf("%w: project name cannot be empty", ErrInvalidConfig)
+	}
+	if config.BinaryName == "" {
+		return nil, fmt.Errorf("%w: binary name cannot be empty", ErrInvalidConfig)
+	}
+
+	// Set defaults
+	if config.LDFlags {
+		config.LDFlags = true
+	}
+	if config.GitProvider == "" {
+		config.GitProvider = "GitHub"
+	}
+	if config.License == "" {
+		config.License = DefaultLicense
+	}
+
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, ConfigTemplate, config); err != nil {
+		return nil, fmt.Errorf("%w: executing %s: %w", ErrTemplate, ConfigFile, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderWorkflow renders the GitHub Actions release workflow
+func renderWorkflow(config *ProjectConfig, templates *template.Template) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, WorkflowTemplate, config); err != nil {
+		return nil, fmt.Errorf("%w: executing %s: %w", ErrTemplate, WorkflowFile, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlQuote renders a string as a double-quoted YAML scalar so hook
+// commands containing ':' or '#' survive parsing
+func yamlQuote(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,529 @@
+package wizard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ProjectConfig
+		wantErr  bool
+		checks   []string // strings that should be in the output
+		excludes []string // strings that must not be in the output
+	}{
+		{
+			name: "basic_config",
+			config: ProjectConfig{
+				ProjectName:        "test-app",
+				ProjectDescription: "A test application",
+				BinaryName:         "test-app",
+				MainPath:           ".",
+				Platforms:          []string{"linux", "darwin"},
+				Architectures:      []string{"amd64", "arm64"},
+				CGOEnabled:         false,
+				GitProvider:        "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"project_name: test-app",
+				"binary: test-app",
+				"- linux",
+				"- darwin",
+				"- amd64",
+				"- arm64",
+				"CGO_ENABLED=0",
+				`owner: "{{.Env.GITHUB_OWNER}}"`,
+				`name: "{{.Env.GITHUB_REPO}}"`,
+			},
+		},
+		{
+			name: "docker_enabled",
+			config: ProjectConfig{
+				ProjectName:    "docker-app",
+				BinaryName:     "docker-app",
+				MainPath:       "./cmd/app",
+				DockerEnabled:  true,
+				DockerRegistry: "ghcr.io/testuser",
+				GitProvider:    "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"dockers:",
+				"image_templates:",
+				"ghcr.io/testuser/docker-app:{{.Tag}}",
+				"dockerfile: Dockerfile",
+			},
+		},
+		{
+			name: "signing_enabled",
+			config: ProjectConfig{
+				ProjectName: "signed-app",
+				BinaryName:  "signed-app",
+				MainPath:    ".",
+				Signing:     true,
+				GitProvider: "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"signs:",
+				"cmd: cosign",
+				"certificate:",
+			},
+		},
+		{
+			name: "homebrew_enabled",
+			config: ProjectConfig{
+				ProjectName:        "brew-app",
+				ProjectDescription: "App with Homebrew support",
+				BinaryName:         "brew-app",
+				MainPath:           ".",
+				Homebrew:           true,
+				License:            "Apache-2.0",
+				GitProvider:        "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"brews:",
+				"repository:",
+				"folder: Formula",
+				"App with Homebrew support",
+				`license: "Apache-2.0"`,
+			},
+		},
+		{
+			name: "architecture_variants",
+			config: ProjectConfig{
+				ProjectName:   "variant-app",
+				BinaryName:    "variant-app",
+				MainPath:      ".",
+				Platforms:     []string{"linux"},
+				Architectures: []string{"amd64", "arm"},
+				GoARM:         []string{"6", "7"},
+				GoAMD64:       []string{"v1", "v3"},
+				BuildOverrides: []BuildOverride{
+					{Goos: "linux", Goarch: "amd64", Goamd64: "v3", Env: []string{"CGO_ENABLED=1"}},
+				},
+				GitProvider: "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"goarm:\n      - \"6\"\n      - \"7\"",
+				"goamd64:\n      - v1\n      - v3",
+				"overrides:\n      - goos: linux\n        goarch: amd64\n        goamd64: v3\n        env:\n          - \"CGO_ENABLED=1\"",
+				"{{with .Arm}}v{{.}}{{end}}",
+				`{{if and .Amd64 (ne .Amd64 "v1")}}_{{.Amd64}}{{end}}`,
+			},
+		},
+		{
+			name: "custom_tags_and_hooks",
+			config: ProjectConfig{
+				ProjectName:    "hook-app",
+				BinaryName:     "hook-app",
+				MainPath:       ".",
+				BuildTags:      []string{"sqlite", "fts5"},
+				Hooks:          []string{"make assets"},
+				PostBuildHooks: []string{"upx {{ .Path }}"},
+				SkipTests:      true,
+				GitProvider:    "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"    - go generate ./...\n    - \"make assets\"\n",
+				"hooks:\n      post:\n        - \"upx {{ .Path }}\"",
+				"tags:\n      - sqlite\n      - fts5",
+			},
+		},
+		{
+			name: "minimal_config",
+			config: ProjectConfig{
+				ProjectName:   "mini-app",
+				BinaryName:    "mini-app",
+				MainPath:      ".",
+				Platforms:     []string{"linux"},
+				Architectures: []string{"amd64"},
+				Minimal:       true,
+				GitProvider:   "GitHub",
+				// Ignored by minimal configs
+				UPX:               true,
+				UniversalBinaries: true,
+				ProVersion:        true,
+				Includes:          []string{"base.yaml"},
+				MonorepoPrefix:    "mini/",
+				MonorepoDir:       "mini",
+				Partial:           true,
+			},
+			wantErr: false,
+			checks: []string{
+				"builds:",
+				"archives:",
+				"checksum:",
+				"release:\n  github:",
+			},
+			excludes: []string{
+				"before:",
+				"snapshot:",
+				"changelog:",
+				"footer:",
+				"universal_binaries:",
+				"upx:",
+				"includes:",
+				"monorepo:",
+				"partial:",
+			},
+		},
+		{
+			name: "free_universal_binaries_and_upx",
+			config: ProjectConfig{
+				ProjectName:       "free-app",
+				BinaryName:        "free-app",
+				MainPath:          ".",
+				Platforms:         []string{"linux", "darwin"},
+				Architectures:     []string{"amd64", "arm64"},
+				UPX:               true,
+				UniversalBinaries: true,
+				GitProvider:       "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"universal_binaries:\n  - id: free-app",
+				"upx:\n  - enabled: true",
+			},
+		},
+		{
+			name: "pro_options",
+			config: ProjectConfig{
+				ProjectName:       "pro-app",
+				BinaryName:        "pro-app",
+				MainPath:          ".",
+				Platforms:         []string{"linux", "darwin", "windows"},
+				Architectures:     []string{"amd64", "arm64"},
+				ProVersion:        true,
+				UniversalBinaries: true,
+				NFPMFormats:       []string{"deb", "rpm"},
+				Scoop:             true,
+				ChangelogGroups:   true,
+				SourceArchive:     true,
+				GitProvider:       "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"mod_timestamp:",
+				"universal_binaries:",
+				"nfpms:",
+				"      - deb\n      - rpm",
+				"scoops:",
+				"groups:",
+				"source:\n  enabled: true",
+			},
+		},
+		{
+			name: "pro_release_flow",
+			config: ProjectConfig{
+				ProjectName:    "flow-app",
+				BinaryName:     "flow-app",
+				MainPath:       ".",
+				ProVersion:     true,
+				Nightly:        true,
+				Partial:        true,
+				MonorepoPrefix: "flow/",
+				MonorepoDir:    "flow",
+				BeforePublish:  []string{"./scripts/notarize.sh"},
+				TemplatedFiles: []TemplatedFile{{Src: "INSTALL.md.tpl", Dst: "INSTALL.md"}},
+				Includes:       []string{"https://example.com/base.yaml", "shared/release.yaml"},
+				GitProvider:    "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"includes:\n  - from_url:\n      url: https://example.com/base.yaml\n  - from_file:\n      path: shared/release.yaml",
+				"monorepo:\n  tag_prefix: flow/\n  dir: flow",
+				"partial:\n  by: goos",
+				"nightly:",
+				"templated_extra_files:\n    - src: INSTALL.md.tpl\n      dst: INSTALL.md",
+				"before_publish:\n  - cmd: \"./scripts/notarize.sh\"",
+			},
+		},
+		{
+			name: "pro_sections_need_pro",
+			config: ProjectConfig{
+				ProjectName: "free-app",
+				BinaryName:  "free-app",
+				MainPath:    ".",
+				Nightly:     true,
+				Partial:     true,
+				Includes:    []string{"shared/release.yaml"},
+				GitProvider: "GitHub",
+			},
+			wantErr:  false,
+			excludes: []string{"nightly:", "partial:", "includes:"},
+		},
+		{
+			name: "raw_binaries_with_upx",
+			config: ProjectConfig{
+				ProjectName:   "upx-app",
+				BinaryName:    "upx-app",
+				MainPath:      ".",
+				Platforms:     []string{"linux", "darwin", "windows"},
+				Architectures: []string{"amd64", "arm64"},
+				Compression:   "none",
+				UPX:           true,
+				GitProvider:   "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"formats:\n      - binary",
+				"upx:\n  - enabled: true",
+				"goos:\n      - linux\n    goarch:\n      - amd64\n      - arm64",
+				"goos:\n      - windows\n    goarch:\n      - amd64\n",
+			},
+			excludes: []string{
+				"format_overrides:",
+				"goos:\n      - darwin\n    goarch:",
+				"### Quick Install",
+			},
+		},
+		{
+			name: "zstd_archives",
+			config: ProjectConfig{
+				ProjectName: "zstd-app",
+				BinaryName:  "zstd-app",
+				MainPath:    ".",
+				Compression: "zstd",
+				GitProvider: "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"formats:\n      - tar.zst",
+				"format_overrides:\n      - goos: windows\n        formats:\n          - zip",
+				"_{{.Arch}}.tar.zst | tar --zstd -x",
+			},
+			excludes: []string{"upx:", ".tar.gz"},
+		},
+		{
+			name: "zip_archives",
+			config: ProjectConfig{
+				ProjectName: "zip-app",
+				BinaryName:  "zip-app",
+				MainPath:    ".",
+				Compression: "zip",
+				GitProvider: "GitHub",
+			},
+			wantErr: false,
+			checks: []string{
+				"formats:\n      - zip",
+				"```bash\n    # Windows (PowerShell)",
+			},
+			excludes: []string{"format_overrides:", "# macOS/Linux", "| tar"},
+		},
+		{
+			name: "missing_project_name",
+			config: ProjectConfig{
+				BinaryName: "test",
+				MainPath:   ".",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing_binary_name",
+			config: ProjectConfig{
+				ProjectName: "test",
+				MainPath:    ".",
+			},
+			wantErr: true,
+		},
+	}
+
+	templates := defaultTemplates(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderConfig(&tt.config, templates)
+
+			// Check error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("renderConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				contentStr := string(content)
+
+				// Check for expected strings
+				for _, check := range tt.checks {
+					if !strings.Contains(contentStr, check) {
+						t.Errorf("Generated config missing expected string: %q", check)
+					}
+				}
+				for _, exclude := range tt.excludes {
+					if strings.Contains(contentStr, exclude) {
+						t.Errorf("Generated config contains unexpected string: %q", exclude)
+					}
+				}
+
+				// Basic YAML structure checks
+				if !strings.HasPrefix(contentStr, "# GoReleaser configuration") {
+					t.Error("Config should start with comment header")
+				}
+				if !strings.Contains(contentStr, "version: 2") {
+					t.Error("Config should specify version 2")
+				}
+			}
+		})
+	}
+}
+
+func TestRenderWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ProjectConfig
+		wantErr bool
+		checks  []string
+	}{
+		{
+			name: "basic_actions",
+			config: ProjectConfig{
+				ProjectName:     "test-app",
+				BinaryName:      "test-app",
+				GenerateActions: true,
+				ActionsOn:       []string{"On version tags (v*)"},
+			},
+			wantErr: false,
+			checks: []string{
+				"name: Release",
+				"tags:",
+				"- 'v*'",
+				"uses: goreleaser/goreleaser-action@v6",
+				"GITHUB_TOKEN:",
+				"GITHUB_OWNER:",
+				"GITHUB_REPO:",
+			},
+		},
+		{
+			name: "docker_support",
+			config: ProjectConfig{
+				ProjectName:     "docker-app",
+				DockerEnabled:   true,
+				DockerRegistry:  "ghcr.io/user",
+				GenerateActions: true,
+				ActionsOn:       []string{"Manual trigger only"},
+			},
+			wantErr: false,
+			checks: []string{
+				"workflow_dispatch:",
+				"Login to Docker Registry",
+				"packages: write",
+			},
+		},
+		{
+			name: "pro_partial_nightly",
+			config: ProjectConfig{
+				ProjectName:     "split-app",
+				Platforms:       []string{"linux", "darwin"},
+				ProVersion:      true,
+				Partial:         true,
+				Nightly:         true,
+				GenerateActions: true,
+				ActionsOn:       []string{"On version tags (v*)"},
+			},
+			wantErr: false,
+			checks: []string{
+				"schedule:",
+				"split:",
+				"args: release --clean --split",
+				"GOOS: ${{ matrix.goos }}",
+				"needs: split",
+				"args: continue --merge",
+				"distribution: goreleaser-pro",
+			},
+		},
+		{
+			name: "minimal_pro_partial",
+			config: ProjectConfig{
+				ProjectName:     "split-app",
+				Minimal:         true,
+				ProVersion:      true,
+				Partial:         true,
+				GenerateActions: true,
+				ActionsOn:       []string{"On version tags (v*)"},
+			},
+			wantErr: false,
+			checks: []string{
+				"args: release --clean\n",
+				"distribution: goreleaser-pro",
+			},
+		},
+		{
+			name: "signing_support",
+			config: ProjectConfig{
+				ProjectName:     "signed-app",
+				Signing:         true,
+				GenerateActions: true,
+				ActionsOn:       []string{"On all tags"},
+			},
+			wantErr: false,
+			checks: []string{
+				"Install Cosign",
+				"id-token: write",
+				"tags:",
+				"- '*'",
+			},
+		},
+	}
+
+	templates := defaultTemplates(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := renderWorkflow(&tt.config, templates)
+
+			// Check error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("renderWorkflow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				contentStr := string(content)
+
+				// Check for expected strings
+				for _, check := range tt.checks {
+					if !strings.Contains(contentStr, check) {
+						t.Errorf("Generated workflow missing expected string: %q", check)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	config := ProjectConfig{ProjectName: "app", BinaryName: "app", MainPath: "."}
+
+	files, err := Render(config)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if len(files) != 1 || files[ConfigFile] == nil {
+		t.Errorf("Render() files = %v, want only %s", len(files), ConfigFile)
+	}
+	if !strings.Contains(string(files[ConfigFile]), "project_name: app") {
+		t.Errorf("Unexpected config:\n%s", files[ConfigFile])
+	}
+	if config.GitProvider != "" {
+		t.Error("Render() modified the caller's config")
+	}
+
+	config.GenerateActions = true
+	config.ActionsOn = []string{"On version tags (v*)"}
+	files, err = Render(config)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(files[WorkflowFile]), "name: Release") {
+		t.Errorf("Render() workflow missing:\n%s", files[WorkflowFile])
+	}
+
+	if _, err := Render(ProjectConfig{}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Render(empty) error = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -0,0 +1,155 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// Root is a project directory. File and git access for a project goes
+// through it, so the project need not be the working directory. The zero
+// Root is the working directory on the local filesystem.
+type Root struct {
+	Dir string
+	// FS holds the project files; nil means OS. Commands always run on the
+	// local filesystem.
+	FS FS
+}
+
+// NewRoot returns the project directory dir on the local filesystem
+func NewRoot(dir string) Root {
+	return Root{Dir: dir}
+}
+
+// fsys returns the filesystem the project is on
+func (r Root) fsys() FS {
+	if r.FS == nil {
+		return OS
+	}
+	return r.FS
+}
+
+// OnDisk reports whether the project is on the local filesystem, so
+// commands run in it see its files. Any FS made by NewFS from an
+// afero.OsFs counts, not only OS.
+func (r Root) OnDisk() bool {
+	a, ok := r.fsys().(aferoFS)
+	if !ok {
+		return false
+	}
+	_, ok = a.fs.(*afero.OsFs)
+	return ok
+}
+
+// Path resolves a slash-separated path relative to the project root;
+// absolute paths are returned unchanged
+func (r Root) Path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(r.Dir, filepath.FromSlash(name))
+}
+
+// Exists reports whether a path in the project exists
+func (r Root) Exists(name string) bool {
+	_, err := r.Stat(name)
+	return err == nil
+}
+
+// Stat describes a path in the project
+func (r Root) Stat(name string) (fs.FileInfo, error) {
+	return r.fsys().Stat(r.Path(name))
+}
+
+// ReadFile reads a file in the project
+func (r Root) ReadFile(name string) ([]byte, error) {
+	return r.fsys().ReadFile(r.Path(name))
+}
+
+// ReadDir lists a directory in the project, sorted by name
+func (r Root) ReadDir(name string) ([]fs.DirEntry, error) {
+	return r.fsys().ReadDir(r.Path(name))
+}
+
+// WriteFile writes a file in the project; its directory must exist
+func (r Root) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return r.fsys().WriteFile(r.Path(name), data, perm)
+}
+
+// WriteFileAtomic writes a file in the project through a temporary file
+// beside it that is renamed into place, so the file holds either its old or
+// its new contents, never a partial write. A replaced file keeps its
+// permissions; its directory must exist.
+func (r Root) WriteFileAtomic(name string, data []byte, perm fs.FileMode) error {
+	fsys, target, tmp := r.fsys(), r.Path(name), r.tempPath(name)
+	if info, err := fsys.Stat(target); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := fsys.WriteFile(tmp, data, perm); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	if err := fsys.Rename(tmp, target); err != nil {
+		fsys.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// tempPath returns an unused OS path beside a file in the project to stage
+// its new contents in
+func (r Root) tempPath(name string) string {
+	target := r.Path(name)
+	return filepath.Join(filepath.Dir(target), fmt.Sprintf(".%s.%d.tmp", filepath.Base(target), rand.Uint32()))
+}
+
+// MkdirAll creates a directory in the project along with its parents
+func (r Root) MkdirAll(name string) error {
+	return r.fsys().MkdirAll(r.Path(name), 0755)
+}
+
+// Remove deletes a file or empty directory in the project
+func (r Root) Remove(name string) error {
+	return r.fsys().Remove(r.Path(name))
+}
+
+// RemoveAll deletes a file or directory in the project with everything in
+// it; a missing path is not an error
+func (r Root) RemoveAll(name string) error {
+	return r.fsys().RemoveAll(r.Path(name))
+}
+
+// Rename moves a file or directory within the project
+func (r Root) Rename(oldname, newname string) error {
+	return r.fsys().Rename(r.Path(oldname), r.Path(newname))
+}
+
+// Glob returns the paths matching a slash-separated pattern, resolved like
+// Path; the results are OS paths
+func (r Root) Glob(pattern string) ([]string, error) {
+	return r.fsys().Glob(r.Path(pattern))
+}
+
+// Command prepares a command, such as git, that runs in the project root
+func (r Root) Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = r.Dir
+	return cmd
+}
+
+// Check returns an error unless the root is an existing directory
+func (r Root) Check() error {
+	info, err := r.Stat(".")
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", r.Path("."))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := NewRoot(dir)
+	if err := os.MkdirAll(filepath.Join(dir, ".github", "workflows"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".github", "workflows", "release.yml"), []byte("name: Release\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := root.Path(WorkflowFile), filepath.Join(dir, ".github", "workflows", "release.yml"); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+	if abs := filepath.Join(t.TempDir(), "x"); root.Path(abs) != abs {
+		t.Errorf("Path(%q) should return absolute paths unchanged", abs)
+	}
+	if !root.Exists(WorkflowFile) || root.Exists(ConfigFile) {
+		t.Error("Exists() does not resolve against the root")
+	}
+	if data, err := root.ReadFile(WorkflowFile); err != nil || string(data) != "name: Release\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	if matches, _ := root.Glob(".github/workflows/*.yml"); len(matches) != 1 {
+		t.Errorf("Glob() = %v", matches)
+	}
+
+	cmd := root.Command(context.Background(), "pwd")
+	if cmd.Dir != dir {
+		t.Errorf("Command().Dir = %q, want %q", cmd.Dir, dir)
+	}
+	if out, err := cmd.Output(); err == nil && !strings.Contains(string(out), filepath.Base(dir)) {
+		t.Errorf("pwd ran in %q", out)
+	}
+
+	if err := root.Check(); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+	if err := NewRoot(filepath.Join(dir, "missing")).Check(); err == nil {
+		t.Error("Check() should fail for a missing directory")
+	}
+	if err := NewRoot(root.Path(WorkflowFile)).Check(); err == nil {
+		t.Error("Check() should fail for a file")
+	}
+}
+
+func TestRootOnDisk(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		root Root
+		want bool
+	}{
+		{"zero", Root{}, true},
+		{"OS", Root{FS: OS}, true},
+		{"afero OsFs", Root{FS: NewFS(afero.NewOsFs())}, true},
+		{"memory", Root{FS: NewMemFS()}, false},
+	} {
+		if got := tt.root.OnDisk(); got != tt.want {
+			t.Errorf("OnDisk() for %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// Rule categories, usable wherever a rule ID is accepted
+const (
+	CategoryConfig  = "config"
+	CategoryProject = "project"
+	CategoryGit     = "git"
+	CategoryTooling = "tooling"
+	CategoryCI      = "ci"
+	CategoryPro     = "pro"
+)
+
+// Rule is one check run by Validate. Findings returned by Check default
+// to the rule's ID and severity. Check must stop work, including child
+// processes, when ctx is done.
+type Rule struct {
+	ID          string
+	Category    string
+	Severity    Severity
+	Description string
+	// Applies reports whether the rule is relevant; nil means always
+	Applies func(rc *RuleContext) bool
+	Check   func(ctx context.Context, rc *RuleContext) CheckResult
+}
+
+// RuleContext is the project state shared by every rule. Rules reach
+// project files and run tools through the embedded Root.
+type RuleContext struct {
+	Root
+	Logger *log.Logger
+	// ConfigFile is the config path relative to Root, as used in findings
+	ConfigFile string
+	// Config is the raw .goreleaser.yaml, nil when it does not exist
+	Config []byte
+	// Doc is the parsed config, nil when missing or invalid
+	Doc      map[string]any
+	ParseErr error
+}
+
+// newRuleContext reads the configuration once for all rules
+func newRuleContext(root Root, logger *log.Logger) *RuleContext {
+	rc := &RuleContext{Root: root, Logger: logger, ConfigFile: ConfigFile}
+	data, err := rc.ReadFile(rc.ConfigFile)
+	if err != nil {
+		return rc
+	}
+	rc.Config = data
+	rc.Doc, rc.ParseErr = parseConfigDoc(data)
+	return rc
+}
+
+// HasConfig reports whether .goreleaser.yaml exists
+func (c *RuleContext) HasConfig() bool {
+	return c.Config != nil
+}
+
+// HasKey reports whether the parsed config has a top-level key
+func (c *RuleContext) HasKey(key string) bool {
+	_, ok := c.Doc[key]
+	return ok
+}
+
+// Pass records a passing check
+func Pass(message, details string) CheckResult {
+	return CheckResult{Passed: true, Message: message, Details: details}
+}
+
+// Fail records a failing check
+func Fail(findings ...Finding) CheckResult {
+	return CheckResult{Findings: findings}
+}
+
+// ruleRegistry holds every rule in registration order
+var ruleRegistry []Rule
+
+// Register adds a rule to Validate. Org-specific rules register the same
+// way from an init function; IDs must be unique.
+func Register(rule Rule) {
+	for _, existing := range ruleRegistry {
+		if existing.ID == rule.ID {
+			panic(fmt.Sprintf("validation rule %q registered twice", rule.ID))
+		}
+	}
+	ruleRegistry = append(ruleRegistry, rule)
+}
+
+// Rules returns the registered rules in registration order
+func Rules() []Rule {
+	return slices.Clone(ruleRegistry)
+}
+
+// SelectRules filters rules by enabled and disabled names. Both accept rule
+// IDs or categories; an empty enable list selects every rule.
+func SelectRules(rules []Rule, enable, disable []string) ([]Rule, error) {
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.ID] = true
+		known[rule.Category] = true
+	}
+	for _, name := range append(slices.Clone(enable), disable...) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown rule or category %q", name)
+		}
+	}
+
+	var selected []Rule
+	for _, rule := range rules {
+		matches := func(names []string) bool {
+			return slices.Contains(names, rule.ID) || slices.Contains(names, rule.Category)
+		}
+		if len(enable) > 0 && !matches(enable) {
+			continue
+		}
+		if matches(disable) {
+			continue
+		}
+		selected = append(selected, rule)
+	}
+	return selected, nil
+}
+
+func init() {
+	Register(Rule{
+		ID:          "config-exists",
+		Category:    CategoryConfig,
+		Severity:    SeverityError,
+		Description: ".goreleaser.yaml must exist",
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			if !rc.HasConfig() {
+				return Fail(Finding{
+					Message: ".goreleaser.yaml not found",
+					Fix:     []string{"Run 'goreleaser-wizard init' to create one"},
+				})
+			}
+			return Pass(".goreleaser.yaml exists", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "config-yaml",
+		Category:    CategoryConfig,
+		Severity:    SeverityError,
+		Description: ".goreleaser.yaml must be valid YAML",
+		Applies:     (*RuleContext).HasConfig,
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			if rc.ParseErr != nil {
+				return Fail(Finding{
+					Message: ".goreleaser.yaml is not valid YAML",
+					File:    rc.ConfigFile,
+					Line:    yamlErrorLine(rc.ParseErr),
+					Details: rc.ParseErr.Error(),
+					Fix:     []string{"Run 'goreleaser-wizard init --force' to regenerate"},
+				})
+			}
+			return Pass(".goreleaser.yaml is valid YAML", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "go-mod",
+		Category:    CategoryProject,
+		Severity:    SeverityError,
+		Description: "go.mod must exist",
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			if !rc.Exists("go.mod") {
+				return Fail(Finding{
+					Message: "go.mod not found",
+					Fix:     []string{"Run 'go mod init <module-name>' to create one"},
+				})
+			}
+			return Pass("go.mod exists", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "main-package",
+		Category:    CategoryProject,
+		Severity:    SeverityWarning,
+		Description: "A main package must exist where builds expect it",
+		Applies:     (*RuleContext).HasConfig,
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			// For simplicity, we'll check common locations
+			for _, path := range []string{"main.go", "cmd/*/main.go", "*.go"} {
+				matches, err := rc.Glob(path)
+				if err != nil {
+					rc.Logger.Debug("Glob pattern error", "pattern", path, "error", err)
+					continue
+				}
+				if len(matches) > 0 {
+					return Pass("Main package found", "")
+				}
+			}
+			return Fail(Finding{
+				Message: "No main.go found in expected locations",
+				File:    rc.ConfigFile,
+				Line:    keyLine(rc.Config, "builds"),
+				Details: "Make sure your main package path is correct in .goreleaser.yaml",
+				Fix:     []string{"Create main.go or update build.main path in config"},
+			})
+		},
+	})
+
+	Register(Rule{
+		ID:          "git-repo",
+		Category:    CategoryGit,
+		Severity:    SeverityWarning,
+		Description: "GoReleaser needs a git repository",
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			if !rc.Exists(".git") {
+				return Fail(Finding{
+					Message: "Not a git repository",
+					Details: "GoReleaser requires a git repository to work",
+					Fix:     []string{"Run 'git init' to initialize repository"},
+				})
+			}
+			return Pass("Git repository found", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "git-clean",
+		Category:    CategoryGit,
+		Severity:    SeverityWarning,
+		Description: "The working tree should have no uncommitted changes",
+		Applies: func(rc *RuleContext) bool {
+			return rc.OnDisk() && rc.Exists(".git")
+		},
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			output, err := rc.Command(ctx, "git", "status", "--porcelain").Output()
+			if err != nil {
+				rc.Logger.Warn("Failed to check git status", "error", err)
+				return Fail(Finding{
+					Message: "Could not check git status",
+					Details: err.Error(),
+				})
+			}
+			if len(output) > 0 {
+				return Fail(Finding{
+					Message: "Uncommitted changes detected",
+					Details: strings.TrimSpace(string(output)),
+					Fix:     []string{"Commit changes with 'git add . && git commit -m \"message\"'"},
+				})
+			}
+			return Pass("Git repository clean", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "goreleaser-installed",
+		Category:    CategoryTooling,
+		Severity:    SeverityError,
+		Description: "The goreleaser binary must be on PATH",
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			path, err := exec.LookPath("goreleaser")
+			if err != nil {
+				rc.Logger.Debug("GoReleaser dependency check", "error", err)
+				return Fail(Finding{
+					Message: "GoReleaser not installed",
+					Fix: []string{
+						"Install with: go install github.com/goreleaser/goreleaser/v2@latest",
+						"Or download from: https://goreleaser.com/install/",
+					},
+				})
+			}
+			return Pass("GoReleaser installed", path)
+		},
+	})
+
+	Register(Rule{
+		ID:          "goreleaser-check",
+		Category:    CategoryConfig,
+		Severity:    SeverityError,
+		Description: "'goreleaser check' must accept the configuration",
+		Applies: func(rc *RuleContext) bool {
+			_, err := exec.LookPath("goreleaser")
+			return rc.OnDisk() && rc.HasConfig() && err == nil
+		},
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			output, err := rc.Command(ctx, "goreleaser", "check").CombinedOutput()
+			if err != nil {
+				rc.Logger.Debug("GoReleaser config validation", "error", err, "output", string(output))
+				return Fail(Finding{
+					Message: "Configuration validation failed",
+					File:    rc.ConfigFile,
+					Details: strings.TrimSpace(string(output)),
+					Fix: []string{
+						"Fix configuration issues in .goreleaser.yaml",
+						"Run 'goreleaser-wizard init --force' to regenerate",
+					},
+				})
+			}
+			return Pass("goreleaser check passed", "")
+		},
+	})
+
+	Register(Rule{
+		ID:          "docker-installed",
+		Category:    CategoryTooling,
+		Severity:    SeverityWarning,
+		Description: "Docker must be installed when Docker images are built",
+		Applies: func(rc *RuleContext) bool {
+			return rc.HasKey("dockers") || rc.Exists("Dockerfile")
+		},
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			path, err := exec.LookPath("docker")
+			if err != nil {
+				rc.Logger.Debug("Docker dependency check", "error", err)
+				finding := Finding{
+					Message: "Docker not installed but Docker images are configured",
+					File:    "Dockerfile",
+					Fix: []string{
+						"Install Docker from https://docker.com/",
+						"Or remove Docker configuration from .goreleaser.yaml",
+					},
+				}
+				if line := keyLine(rc.Config, "dockers"); line > 0 {
+					finding.File, finding.Line = rc.ConfigFile, line
+				}
+				return Fail(finding)
+			}
+			return Pass("Docker installed", path)
+		},
+	})
+
+	Register(Rule{
+		ID:          "upx-installed",
+		Category:    CategoryTooling,
+		Severity:    SeverityError,
+		Description: "upx must be installed when the upx section is configured",
+		Applies: func(rc *RuleContext) bool {
+			return rc.Doc["upx"] != nil
+		},
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			path, err := exec.LookPath("upx")
+			if err != nil {
+				rc.Logger.Debug("UPX dependency check", "error", err)
+				return Fail(Finding{
+					Message: "UPX configured but not installed",
+					File:    rc.ConfigFile,
+					Line:    keyLine(rc.Config, "upx"),
+					Fix: []string{
+						"Install UPX from https://upx.github.io/",
+						"Or remove the upx section from .goreleaser.yaml",
+					},
+				})
+			}
+			return Pass("UPX installed", path)
+		},
+	})
+
+	Register(Rule{
+		ID:          "release-workflow",
+		Category:    CategoryCI,
+		Severity:    SeverityNote,
+		Description: "A GitHub Actions release workflow should exist",
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			if rc.Exists(".github/workflows/release.yml") || rc.Exists(".github/workflows/release.yaml") {
+				return Pass("GitHub Actions workflow found", "")
+			}
+			return Fail(Finding{
+				Message: "No GitHub Actions workflow for releases",
+				Fix: []string{
+					"Run 'goreleaser-wizard init' with GitHub Actions option",
+					"Or manually create .github/workflows/release.yml",
+				},
+			})
+		},
+	})
+
+	Register(Rule{
+		ID:          "pro-only-keys",
+		Category:    CategoryPro,
+		Severity:    SeverityError,
+		Description: "GoReleaser Pro keys need the Pro distribution",
+		Applies: func(rc *RuleContext) bool {
+			return rc.Doc != nil
+		},
+		Check: func(ctx context.Context, rc *RuleContext) CheckResult {
+			proKeys, _ := findProOnlyKeys(rc.Config)
+			if len(proKeys) == 0 || usesProDistribution(rc.Root) {
+				return Pass("No Pro-only keys without GoReleaser Pro", "")
+			}
+			var findings []Finding
+			for _, key := range proKeys {
+				line := keyLine(rc.Config, key)
+				if line == 0 {
+					line = keyLine(rc.Config, "release", strings.TrimPrefix(key, "release."))
+				}
+				findings = append(findings, Finding{
+					Message: fmt.Sprintf("Pro-only key '%s' used without GoReleaser Pro", key),
+					File:    rc.ConfigFile,
+					Line:    line,
+					Fix: []string{
+						"Set 'distribution: goreleaser-pro' in your release workflow",
+						"Or remove the Pro-only sections from .goreleaser.yaml",
+					},
+				})
+			}
+			return Fail(findings...)
+		},
+	})
+}
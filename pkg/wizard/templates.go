@@ -0,0 +1,90 @@
+package wizard
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Template names executed for each release file. Partials are named after
+// their path, e.g. "partials/builds".
+const (
+	ConfigTemplate   = "goreleaser.yaml"
+	WorkflowTemplate = "release.yml"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// Templates holds the built-in templates as *.tmpl files, each defining the
+// template named after its path without the extension. It is the starting
+// point for a templates directory passed to LoadTemplates.
+var Templates = func() fs.FS {
+	sub, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}()
+
+// templateFuncs are the functions available to every template
+var templateFuncs = template.FuncMap{
+	"yamlQuote":       yamlQuote,
+	"isRemoteInclude": isRemoteInclude,
+	"archiveFormat":   archiveFormat,
+	"tarExtract":      tarExtract,
+	"upxTargets":      upxTargets,
+	"contains":        strings.Contains,
+	"splitAnswer":     SplitAnswer,
+}
+
+// LoadTemplates parses the built-in templates with the *.tmpl files under
+// each dir layered on top, in order. A file replaces the earlier file at the
+// same path, so a single partial can be overridden; other files are added
+// and may redefine templates. Empty dirs are skipped.
+func LoadTemplates(dirs ...string) (*template.Template, error) {
+	files := make(map[string][]byte)
+	var order []string
+	collect := func(fsys fs.FS) error {
+		return fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+				return nil
+			}
+			data, err := fs.ReadFile(fsys, path)
+			if err != nil {
+				return err
+			}
+			if _, ok := files[path]; !ok {
+				order = append(order, path)
+			}
+			files[path] = data
+			return nil
+		})
+	}
+
+	if err := collect(Templates); err != nil {
+		return nil, fmt.Errorf("%w: reading built-in templates: %w", ErrTemplate, err)
+	}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if err := collect(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("%w: reading templates from %s: %w", ErrTemplate, dir, err)
+		}
+	}
+
+	t := template.New("").Funcs(templateFuncs)
+	for _, path := range order {
+		if _, err := t.New(path).Parse(string(files[path])); err != nil {
+			return nil, fmt.Errorf("%w: parsing %s: %w", ErrTemplate, path, err)
+		}
+	}
+	return t, nil
+}
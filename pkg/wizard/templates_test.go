@@ -0,0 +1,87 @@
+package wizard
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func defaultTemplates(t *testing.T) *template.Template {
+	t.Helper()
+	templates, err := LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return templates
+}
+
+func TestLoadTemplates(t *testing.T) {
+	templates := defaultTemplates(t)
+	for _, name := range []string{ConfigTemplate, WorkflowTemplate, "partials/builds", "partials/workflow-triggers"} {
+		if templates.Lookup(name) == nil {
+			t.Errorf("Built-in template %q not defined", name)
+		}
+	}
+
+	// Every built-in file defines the template named after its path
+	err := fs.WalkDir(Templates, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if name := strings.TrimSuffix(path, ".tmpl"); templates.Lookup(name) == nil {
+			t.Errorf("%s does not define %q", path, name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTemplatesOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "partials"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Override one partial; an extra file adds a partial the override uses
+	overrides := map[string]string{
+		"partials/changelog.tmpl": `{{define "partials/changelog"}}{{template "partials/company" .}}{{end}}`,
+		"partials/company.tmpl":   `{{define "partials/company"}}# company changelog policy for {{.ProjectName}}` + "\n{{end}}\n",
+		"README.md":               "not a template {{",
+	}
+	for name, content := range overrides {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := RenderWith(ProjectConfig{ProjectName: "demo", BinaryName: "demo", MainPath: "."}, templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := string(files[ConfigFile])
+	if !strings.Contains(config, "# company changelog policy for demo") {
+		t.Error("Overridden partial not used")
+	}
+	if strings.Contains(config, "changelog:") || !strings.Contains(config, "builds:") {
+		t.Error("Override should replace only the changelog partial")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "partials", "builds.tmpl"), []byte(`{{define "partials/builds"}}{{.Nope}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTemplates(dir); !errors.Is(err, ErrTemplate) {
+		t.Errorf("LoadTemplates() with a broken override = %v, want ErrTemplate", err)
+	}
+	if _, err := LoadTemplates(filepath.Join(dir, "missing")); !errors.Is(err, ErrTemplate) {
+		t.Errorf("LoadTemplates() with a missing dir = %v, want ErrTemplate", err)
+	}
+}
@@ -0,0 +1,348 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity ranks a validation finding, using SARIF level names
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Finding is one problem reported by a validation check
+type Finding struct {
+	RuleID   string   `json:"ruleId"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Details  string   `json:"details,omitempty"`
+	Fix      []string `json:"fix,omitempty"`
+	// SuppressedBy is "config" or "inline" for suppressed findings
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+}
+
+// CheckResult is the outcome of one validation rule. A passed check has
+// no findings; Details carries extra context for verbose output.
+type CheckResult struct {
+	Rule     string
+	Passed   bool
+	Message  string
+	Details  string
+	Findings []Finding
+	Duration time.Duration
+}
+
+// Report collects the outcome of every rule run by Validate
+type Report struct {
+	Rules  []Rule
+	Checks []CheckResult
+	// Suppressed holds findings silenced by config or wizard:ignore comments
+	Suppressed []Finding
+}
+
+// Passed returns the number of passed checks
+func (r *Report) Passed() int {
+	passed := 0
+	for _, check := range r.Checks {
+		if check.Passed {
+			passed++
+		}
+	}
+	return passed
+}
+
+// Findings returns every finding in check order
+func (r *Report) Findings() []Finding {
+	findings := []Finding{}
+	for _, check := range r.Checks {
+		findings = append(findings, check.Findings...)
+	}
+	return findings
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings() {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	// yamlLinePattern matches the "line N" position in yaml.v3 errors
+	yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+	// ignorePattern matches "# wizard:ignore RULE[,RULE...]" comments
+	ignorePattern = regexp.MustCompile(`#\s*wizard:ignore\b([^#]*)`)
+)
+
+// How a finding was suppressed, as recorded in Finding.SuppressedBy
+const (
+	SuppressedConfig = "config"
+	SuppressedInline = "inline"
+)
+
+// DefaultTimeout limits each check when Options.Timeout is zero
+const DefaultTimeout = 30 * time.Second
+
+// Options tune ValidateContext
+type Options struct {
+	// Rules to run; nil runs every registered rule
+	Rules []Rule
+	// Ignore suppresses findings from these rule IDs or categories
+	Ignore []string
+	// Timeout limits each check; zero means DefaultTimeout
+	Timeout time.Duration
+	// Logger receives debug output from rules; nil discards it
+	Logger *log.Logger
+}
+
+// Validate runs every registered rule against the project in dir
+func Validate(dir string) (Report, error) {
+	return ValidateContext(context.Background(), dir, Options{})
+}
+
+// ValidateContext runs the selected rules against the project in dir on the
+// local filesystem; see Root.Validate
+func ValidateContext(ctx context.Context, dir string, opts Options) (Report, error) {
+	return NewRoot(dir).Validate(ctx, opts)
+}
+
+// Validate runs the selected rules against the project. Rules run
+// concurrently; cancelling ctx stops them and reports what finished. The
+// error is only set when the root cannot be read, not for failed checks.
+func (r Root) Validate(ctx context.Context, opts Options) (Report, error) {
+	if err := r.Check(); err != nil {
+		return Report{}, err
+	}
+
+	if opts.Rules == nil {
+		opts.Rules = Rules()
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.New(io.Discard)
+	}
+
+	report := runRules(ctx, newRuleContext(r, opts.Logger), opts.Rules, opts.Ignore, opts.Timeout)
+	return *report, nil
+}
+
+// runRules runs every applicable rule concurrently, each under its own
+// timeout, and reports results in rule order. Findings matching ignored
+// rule IDs or categories, or a wizard:ignore comment, are moved to
+// report.Suppressed; a check whose findings are all suppressed passes.
+func runRules(ctx context.Context, rc *RuleContext, rules []Rule, ignored []string, timeout time.Duration) *Report {
+	report := &Report{Rules: rules}
+
+	results := make([]*CheckResult, len(rules))
+	var wg sync.WaitGroup
+	for i, rule := range rules {
+		if rule.Applies != nil && !rule.Applies(rc) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := runCheck(ctx, rc, rule, timeout)
+			results[i] = &result
+		}()
+	}
+	wg.Wait()
+
+	comments := make(map[string]map[int][]string)
+	for i, rule := range rules {
+		result := results[i]
+		if result == nil {
+			continue
+		}
+
+		var kept []Finding
+		for _, finding := range result.Findings {
+			if finding.RuleID == "" {
+				finding.RuleID = rule.ID
+			}
+			if finding.Severity == "" {
+				finding.Severity = rule.Severity
+			}
+
+			switch {
+			case slices.Contains(ignored, finding.RuleID) || slices.Contains(ignored, rule.Category):
+				finding.SuppressedBy = SuppressedConfig
+			case finding.File != "" && finding.Line > 0:
+				if _, ok := comments[finding.File]; !ok {
+					comments[finding.File] = inlineIgnores(rc.Root, finding.File)
+				}
+				if ignoredInline(comments[finding.File], finding) {
+					finding.SuppressedBy = SuppressedInline
+				}
+			}
+
+			if finding.SuppressedBy != "" {
+				report.Suppressed = append(report.Suppressed, finding)
+			} else {
+				kept = append(kept, finding)
+			}
+		}
+
+		if !result.Passed && len(kept) == 0 {
+			result.Passed = true
+			result.Message = rule.Description + " (suppressed)"
+		}
+		result.Findings = kept
+		report.Checks = append(report.Checks, *result)
+	}
+
+	return report
+}
+
+// runCheck runs one rule with a timeout. A rule that times out, is
+// cancelled or panics fails with a finding saying so.
+func runCheck(ctx context.Context, rc *RuleContext, rule Rule, timeout time.Duration) CheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan CheckResult, 1)
+	// late is set when the check returned after its context ended
+	var late bool
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- Fail(Finding{Message: fmt.Sprintf("Rule %s crashed", rule.ID), Details: fmt.Sprint(r)})
+			}
+		}()
+		result := rule.Check(checkCtx, rc)
+		late = checkCtx.Err() != nil
+		done <- result
+	}()
+
+	var result CheckResult
+	finished := false
+	select {
+	case result = <-done:
+		finished = !late
+	case <-checkCtx.Done():
+	}
+
+	// A check that returned in time keeps its result even if the context
+	// ends afterwards; one still running, or stopped by its context,
+	// reports why rather than the kill itself
+	if !finished {
+		if ctx.Err() != nil {
+			result = Fail(Finding{Message: fmt.Sprintf("Rule %s cancelled", rule.ID), Details: ctx.Err().Error()})
+		} else {
+			result = Fail(Finding{
+				Message: fmt.Sprintf("Rule %s timed out after %s", rule.ID, timeout),
+				Fix:     []string{"Raise the limit with --timeout or skip the rule with --disable " + rule.ID},
+			})
+		}
+	}
+	result.Rule = rule.ID
+	result.Duration = time.Since(start)
+	return result
+}
+
+// parseConfigDoc parses a .goreleaser.yaml document into its top-level keys
+func parseConfigDoc(data []byte) (map[string]any, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// keyLine returns the 1-based line of the mapping key at path, or 0 when absent
+func keyLine(data []byte, path ...string) int {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return 0
+	}
+	node := root.Content[0]
+	line := 0
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return 0
+		}
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				node = node.Content[i+1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	return line
+}
+
+// yamlErrorLine extracts the line number from a yaml.v3 error message
+func yamlErrorLine(err error) int {
+	match := yamlLinePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(match[1])
+	return line
+}
+
+// inlineIgnores maps 1-based line numbers to the rules ignored by a
+// wizard:ignore comment on that line; "*" ignores every rule
+func inlineIgnores(root Root, file string) map[int][]string {
+	data, err := root.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	ignores := make(map[int][]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		match := ignorePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		rules := strings.FieldsFunc(match[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(rules) == 0 {
+			rules = []string{"*"}
+		}
+		ignores[i+1] = rules
+	}
+	return ignores
+}
+
+// ignoredInline reports whether a wizard:ignore comment on the finding's
+// line, or on the line above it, names the finding's rule
+func ignoredInline(ignores map[int][]string, finding Finding) bool {
+	for _, line := range []int{finding.Line, finding.Line - 1} {
+		rules := ignores[line]
+		if slices.Contains(rules, "*") || slices.Contains(rules, finding.RuleID) {
+			return true
+		}
+	}
+	return false
+}
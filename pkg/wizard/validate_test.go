@@ -0,0 +1,234 @@
+package wizard
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestRuleRegistry(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, rule := range ruleRegistry {
+		if rule.ID == "" || rule.Category == "" || rule.Severity == "" || rule.Check == nil {
+			t.Errorf("Rule %+v is incomplete", rule)
+		}
+		if seen[rule.ID] {
+			t.Errorf("Duplicate rule %q", rule.ID)
+		}
+		seen[rule.ID] = true
+	}
+}
+
+func TestSelectRules(t *testing.T) {
+	ids := func(rules []Rule) []string {
+		var out []string
+		for _, rule := range rules {
+			out = append(out, rule.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		enable  []string
+		disable []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "enable_category", enable: []string{CategoryGit}, want: []string{"git-repo", "git-clean"}},
+		{name: "enable_minus_disable", enable: []string{CategoryGit}, disable: []string{"git-clean"}, want: []string{"git-repo"}},
+		{name: "enable_rule", enable: []string{"upx-installed"}, want: []string{"upx-installed"}},
+		{name: "unknown", disable: []string{"no-such-rule"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := SelectRules(ruleRegistry, tt.enable, tt.disable)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := ids(rules); !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("SelectRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	all, _ := SelectRules(ruleRegistry, nil, nil)
+	if len(all) != len(ruleRegistry) {
+		t.Errorf("No filters selected %d rules, want %d", len(all), len(ruleRegistry))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, _ := SelectRules(Rules(), []string{"config-exists", "go-mod", "release-workflow"}, nil)
+	report, err := ValidateContext(context.Background(), dir, Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("ValidateContext() error = %v", err)
+	}
+
+	results := make(map[string]bool)
+	for _, check := range report.Checks {
+		results[check.Rule] = check.Passed
+	}
+	want := map[string]bool{"config-exists": false, "go-mod": true, "release-workflow": false}
+	for id, passed := range want {
+		if got, ok := results[id]; !ok || got != passed {
+			t.Errorf("%s passed = %v (ran: %v), want %v", id, got, ok, passed)
+		}
+	}
+
+	if _, err := Validate(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for a missing directory")
+	}
+}
+
+func TestRunRulesSuppression(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".goreleaser.yaml")
+	content := "version: 2\n# wizard:ignore custom-line\nupx:\n  - enabled: true # wizard:ignore\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	finding := func(line int) func(context.Context, *RuleContext) CheckResult {
+		return func(context.Context, *RuleContext) CheckResult {
+			return Fail(Finding{Message: "problem", File: file, Line: line})
+		}
+	}
+	rules := []Rule{
+		{ID: "custom-line", Category: "org", Severity: SeverityError, Check: finding(3)},
+		{ID: "custom-any", Category: "org", Severity: SeverityError, Check: finding(4)},
+		{ID: "custom-config", Category: "org", Severity: SeverityWarning, Check: finding(1)},
+		{ID: "custom-kept", Category: "team", Severity: SeverityError, Check: finding(1)},
+		{ID: "custom-skipped", Category: "team", Severity: SeverityError, Check: finding(1),
+			Applies: func(*RuleContext) bool { return false }},
+	}
+
+	report := runRules(context.Background(), &RuleContext{Logger: log.New(os.Stderr)}, rules, []string{"custom-config"}, time.Minute)
+
+	if len(report.Checks) != 4 {
+		t.Fatalf("Checks = %d, want 4 (inapplicable rule skipped)", len(report.Checks))
+	}
+	if report.Passed() != 3 {
+		t.Errorf("Passed = %d, want 3 (suppressed checks pass)", report.Passed())
+	}
+	findings := report.Findings()
+	if len(findings) != 1 || findings[0].RuleID != "custom-kept" || findings[0].Severity != SeverityError {
+		t.Errorf("Findings = %+v, want only custom-kept", findings)
+	}
+
+	suppressedBy := make(map[string]string)
+	for _, finding := range report.Suppressed {
+		suppressedBy[finding.RuleID] = finding.SuppressedBy
+	}
+	want := map[string]string{
+		"custom-line":   SuppressedInline,
+		"custom-any":    SuppressedInline,
+		"custom-config": SuppressedConfig,
+	}
+	for id, by := range want {
+		if suppressedBy[id] != by {
+			t.Errorf("%s suppressed by %q, want %q", id, suppressedBy[id], by)
+		}
+	}
+}
+
+func TestRunRulesTimeoutAndOrder(t *testing.T) {
+	slow := func(ctx context.Context, _ *RuleContext) CheckResult {
+		<-ctx.Done()
+		return Pass("never", "")
+	}
+	fast := func(delay time.Duration, message string) func(context.Context, *RuleContext) CheckResult {
+		return func(context.Context, *RuleContext) CheckResult {
+			time.Sleep(delay)
+			return Pass(message, "")
+		}
+	}
+	rules := []Rule{
+		{ID: "first", Category: "org", Severity: SeverityError, Check: fast(30*time.Millisecond, "first")},
+		{ID: "hangs", Category: "org", Severity: SeverityWarning, Check: slow},
+		{ID: "last", Category: "org", Severity: SeverityError, Check: fast(0, "last")},
+		{ID: "panics", Category: "org", Severity: SeverityError, Check: func(context.Context, *RuleContext) CheckResult {
+			panic("boom")
+		}},
+	}
+
+	start := time.Now()
+	report := runRules(context.Background(), &RuleContext{Logger: log.New(os.Stderr)}, rules, nil, 100*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Checks did not run concurrently with a timeout: took %s", elapsed)
+	}
+
+	var order []string
+	for _, check := range report.Checks {
+		order = append(order, check.Rule)
+		if check.Duration <= 0 {
+			t.Errorf("%s has no duration", check.Rule)
+		}
+	}
+	if want := []string{"first", "hangs", "last", "panics"}; !slices.Equal(order, want) {
+		t.Errorf("Order = %v, want %v", order, want)
+	}
+
+	findings := report.Findings()
+	if len(findings) != 2 {
+		t.Fatalf("Findings = %+v, want timeout and panic", findings)
+	}
+	if findings[0].RuleID != "hangs" || findings[0].Severity != SeverityWarning || !strings.Contains(findings[0].Message, "timed out") {
+		t.Errorf("Timeout finding = %+v", findings[0])
+	}
+	if findings[1].RuleID != "panics" || !strings.Contains(findings[1].Details, "boom") {
+		t.Errorf("Panic finding = %+v", findings[1])
+	}
+}
+
+func TestRunRulesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rules := []Rule{{ID: "waits", Category: "org", Severity: SeverityError, Check: func(ctx context.Context, _ *RuleContext) CheckResult {
+		<-ctx.Done()
+		return Pass("never", "")
+	}}}
+	report := runRules(ctx, &RuleContext{Logger: log.New(os.Stderr)}, rules, nil, time.Minute)
+	if findings := report.Findings(); len(findings) != 1 || !strings.Contains(findings[0].Message, "cancelled") {
+		t.Errorf("Findings = %+v, want cancellation", findings)
+	}
+}
+
+func TestKeyLine(t *testing.T) {
+	data := []byte("version: 2\nbuilds:\n  - id: app\nrelease:\n  templated_extra_files:\n    - src: a\n")
+
+	tests := []struct {
+		path []string
+		want int
+	}{
+		{[]string{"version"}, 1},
+		{[]string{"builds"}, 2},
+		{[]string{"release", "templated_extra_files"}, 5},
+		{[]string{"nightly"}, 0},
+	}
+	for _, tt := range tests {
+		if got := keyLine(data, tt.path...); got != tt.want {
+			t.Errorf("keyLine(%v) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+
+	_, err := parseConfigDoc([]byte("version: 2\nbuilds:\n  - id: [\n"))
+	if err == nil {
+		t.Fatal("Expected YAML error")
+	}
+	if line := yamlErrorLine(err); line == 0 {
+		t.Errorf("yamlErrorLine(%q) = 0", err)
+	}
+}